@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"botcha/puzzles/puzzletest"
+)
+
+// runAudit reports how often the deliberately dumb solvers of puzzletest beat
+// each built-in puzzle and returns the process exit code, which is 1 if any
+// solver beats more than puzzletest.MaxGuessRate of a puzzle's challenges
+func runAudit(args []string) int {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	n := fs.Int("n", 200, "challenges to generate per puzzle")
+	attempts := fs.Int("attempts", maxAttempts, "guesses each solver gets per challenge")
+	fs.Parse(args)
+
+	solvers := puzzletest.DumbSolvers()
+	fmt.Printf("%-14s", "puzzle")
+	for _, s := range solvers {
+		fmt.Printf(" %13s", s.Name)
+	}
+	fmt.Println()
+
+	var failed []string
+	for _, c := range puzzletest.BuiltinCases() {
+		r := puzzletest.Audit(c.Puzzle, *n, *attempts, solvers)
+		fmt.Printf("%-14s", r.Puzzle)
+		for _, s := range solvers {
+			fmt.Printf(" %12.1f%%", 100*r.Rate(s.Name))
+		}
+		fmt.Println()
+		for _, s := range c.OverGuessRate(r) {
+			failed = append(failed, r.Puzzle+" ("+s+")")
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Printf("\nAbove the %.0f%% limit: %s\n", 100*puzzletest.MaxGuessRate, strings.Join(failed, ", "))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,362 @@
+package challenge
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+// adaptiveTolerance is how far the observed success rate may drift from
+// the target before the difficulty is adjusted
+const adaptiveTolerance = 0.1
+
+// AdaptiveConfig controls how puzzle difficulty follows observed solve statistics
+type AdaptiveConfig struct {
+	// TargetSuccessRate is the fraction of challenges that should be solved (0..1)
+	TargetSuccessRate float64
+	// TargetLatency is the desired mean solve time of successful answers
+	TargetLatency time.Duration
+	// Window is the number of outcomes collected before each adjustment
+	Window int
+	// MaxPerClient caps how many outcomes of one client network (an IPv4 /24
+	// or IPv6 /64) a window may hold, so a single client fetching challenges
+	// and failing them on purpose cannot drag the difficulty down
+	MaxPerClient int
+
+	// Difficulty and time limit never leave these bounds
+	MinDifficulty Difficulty
+	MaxDifficulty Difficulty
+	MinTimeLimit  time.Duration
+	MaxTimeLimit  time.Duration
+	TimeLimitStep time.Duration
+}
+
+// DefaultAdaptiveConfig returns bounds that keep the built-in puzzles solvable.
+// With fewer than 4 decoy letters, few words form enough rivals for the
+// scramble puzzles. Word lengths start at 8, the shortest in the corpus, so
+// every step of MinWordLength changes the words that can be drawn.
+func DefaultAdaptiveConfig() AdaptiveConfig {
+	return AdaptiveConfig{
+		TargetSuccessRate: 0.8,
+		TargetLatency:     15 * time.Second,
+		Window:            20,
+		MaxPerClient:      2,
+		MinDifficulty:     Difficulty{HiddenPositions: 1, MinWordLength: 8, ExtraLetters: 4},
+		MaxDifficulty:     Difficulty{HiddenPositions: 4, MinWordLength: 16, ExtraLetters: 7},
+		MinTimeLimit:      10 * time.Second,
+		MaxTimeLimit:      60 * time.Second,
+		TimeLimitStep:     5 * time.Second,
+	}
+}
+
+type outcomeKind int
+
+const (
+	outcomeSolved outcomeKind = iota
+	outcomeWrong
+	outcomeExpired
+)
+
+type outcome struct {
+	kind    outcomeKind
+	elapsed time.Duration
+	network netip.Prefix
+}
+
+// puzzleStats holds the rolling statistics and current time limit of one puzzle
+type puzzleStats struct {
+	timeLimit   time.Duration
+	window      []outcome
+	total       int
+	solved      int
+	tooFast     int
+	normalized  int
+	adjustments int
+}
+
+// EnableAdaptive turns on automatic difficulty adjustment.
+// Must be called after all puzzles are registered and before serving requests.
+func (c *BotchaMiddleware) EnableAdaptive(cfg AdaptiveConfig) {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultAdaptiveConfig().Window
+	}
+	if cfg.MaxPerClient <= 0 {
+		cfg.MaxPerClient = cfg.Window
+	}
+	c.adaptive = &cfg
+	for name, p := range c.puzzles {
+		if t, ok := p.(Tunable); ok {
+			t.SetDifficulty(clampDifficulty(t.Difficulty(), cfg.MinDifficulty, cfg.MaxDifficulty))
+		}
+		s := c.statsFor(name)
+		s.timeLimit = clampDuration(s.timeLimit, cfg.MinTimeLimit, cfg.MaxTimeLimit)
+	}
+	log.Printf("Adaptive difficulty enabled: target success %.0f%%, target latency %s",
+		cfg.TargetSuccessRate*100, cfg.TargetLatency)
+}
+
+// statsFor returns the statistics of the named puzzle, creating them on first use.
+// Must be called with statsMu held or before serving starts.
+func (c *BotchaMiddleware) statsFor(puzzleName string) *puzzleStats {
+	s, ok := c.stats[puzzleName]
+	if !ok {
+		s = &puzzleStats{timeLimit: sessionTimeout}
+		c.stats[puzzleName] = s
+	}
+	return s
+}
+
+// timeLimitFor returns the current time limit of the named puzzle
+func (c *BotchaMiddleware) timeLimitFor(puzzleName string) time.Duration {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	return c.statsFor(puzzleName).timeLimit
+}
+
+// recordOutcome adds a validation result to the rolling statistics and
+// adjusts the puzzle once a full window has been collected
+func (c *BotchaMiddleware) recordOutcome(puzzleName string, client ClientInfo, kind outcomeKind, elapsed time.Duration) {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+
+	s := c.statsFor(puzzleName)
+	s.total++
+	if kind == outcomeSolved {
+		s.solved++
+	}
+	if c.adaptive == nil {
+		return
+	}
+
+	// Outcomes beyond a client's share are counted but do not steer difficulty
+	network := clientNetwork(client.Addr)
+	fromClient := 0
+	for _, o := range s.window {
+		if o.network == network {
+			fromClient++
+		}
+	}
+	if fromClient >= c.adaptive.MaxPerClient {
+		return
+	}
+	s.window = append(s.window, outcome{kind: kind, elapsed: elapsed, network: network})
+
+	if len(s.window) < c.adaptive.Window {
+		return
+	}
+	c.adjust(puzzleName, s)
+	s.window = s.window[:0]
+}
+
+// recordTooFast counts a submission that beat the minimum solve time.
+// These are kept out of the rolling window so scripted solvers cannot steer difficulty.
+func (c *BotchaMiddleware) recordTooFast(puzzleName string) {
+	c.statsMu.Lock()
+	c.statsFor(puzzleName).tooFast++
+	c.statsMu.Unlock()
+}
+
+// recordNormalized counts a solve that was only accepted after answer normalization
+func (c *BotchaMiddleware) recordNormalized(puzzleName string) {
+	c.statsMu.Lock()
+	c.statsFor(puzzleName).normalized++
+	c.statsMu.Unlock()
+}
+
+// clientNetwork returns the IPv4 /24 or IPv6 /64 an address belongs to.
+// Requests without a parseable address all share the zero prefix.
+func clientNetwork(addr netip.Addr) netip.Prefix {
+	bits := 64
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return netip.Prefix{}
+	}
+	return prefix
+}
+
+// adjust moves the difficulty or time limit of a puzzle toward the target.
+// Must be called with statsMu held.
+func (c *BotchaMiddleware) adjust(puzzleName string, s *puzzleStats) {
+	cfg := c.adaptive
+	rate, latency := windowSummary(s.window)
+
+	var tunable Tunable
+	if t, ok := c.puzzles[puzzleName].(Tunable); ok {
+		tunable = t
+	}
+
+	switch {
+	case rate > cfg.TargetSuccessRate+adaptiveTolerance && latency < c.targetLatencyFor(puzzleName):
+		// Too easy: raise difficulty first, then tighten the clock
+		if tunable != nil {
+			if d, ok := harder(tunable.Difficulty(), cfg.MaxDifficulty); ok {
+				tunable.SetDifficulty(d)
+				c.logAdjustment(puzzleName, s, "harder", rate, latency)
+				return
+			}
+		}
+		if s.timeLimit-cfg.TimeLimitStep >= cfg.MinTimeLimit {
+			s.timeLimit -= cfg.TimeLimitStep
+			c.logAdjustment(puzzleName, s, "shorter time limit", rate, latency)
+		}
+
+	case rate < cfg.TargetSuccessRate-adaptiveTolerance:
+		// Too hard: give more time if agents mostly run out of it,
+		// otherwise lower the difficulty
+		expired, wrong := 0, 0
+		for _, o := range s.window {
+			switch o.kind {
+			case outcomeExpired:
+				expired++
+			case outcomeWrong:
+				wrong++
+			}
+		}
+		if expired >= wrong && s.timeLimit+cfg.TimeLimitStep <= cfg.MaxTimeLimit {
+			s.timeLimit += cfg.TimeLimitStep
+			c.logAdjustment(puzzleName, s, "longer time limit", rate, latency)
+			return
+		}
+		if tunable != nil {
+			if d, ok := easier(tunable.Difficulty(), cfg.MinDifficulty); ok {
+				tunable.SetDifficulty(d)
+				c.logAdjustment(puzzleName, s, "easier", rate, latency)
+				return
+			}
+		}
+		if s.timeLimit+cfg.TimeLimitStep <= cfg.MaxTimeLimit {
+			s.timeLimit += cfg.TimeLimitStep
+			c.logAdjustment(puzzleName, s, "longer time limit", rate, latency)
+		}
+	}
+}
+
+func (c *BotchaMiddleware) logAdjustment(puzzleName string, s *puzzleStats, change string, rate float64, latency time.Duration) {
+	s.adjustments++
+	log.Printf("Adaptive: puzzle=%s %s (success %.0f%%, mean latency %.1fs, time limit %s)",
+		puzzleName, change, rate*100, latency.Seconds(), s.timeLimit)
+}
+
+// windowSummary returns the success rate and the mean solve time of successful outcomes
+func windowSummary(window []outcome) (rate float64, latency time.Duration) {
+	if len(window) == 0 {
+		return 0, 0
+	}
+	solved := 0
+	var total time.Duration
+	for _, o := range window {
+		if o.kind == outcomeSolved {
+			solved++
+			total += o.elapsed
+		}
+	}
+	if solved > 0 {
+		latency = total / time.Duration(solved)
+	}
+	return float64(solved) / float64(len(window)), latency
+}
+
+// harder raises the first knob that is still below its maximum
+func harder(d, limit Difficulty) (Difficulty, bool) {
+	switch {
+	case d.ExtraLetters < limit.ExtraLetters:
+		d.ExtraLetters++
+	case d.HiddenPositions < limit.HiddenPositions:
+		d.HiddenPositions++
+	case d.MinWordLength < limit.MinWordLength:
+		d.MinWordLength++
+	default:
+		return d, false
+	}
+	return d, true
+}
+
+// easier lowers knobs in the reverse order of harder
+func easier(d, limit Difficulty) (Difficulty, bool) {
+	switch {
+	case d.MinWordLength > limit.MinWordLength:
+		d.MinWordLength--
+	case d.HiddenPositions > limit.HiddenPositions:
+		d.HiddenPositions--
+	case d.ExtraLetters > limit.ExtraLetters:
+		d.ExtraLetters--
+	default:
+		return d, false
+	}
+	return d, true
+}
+
+func clampDifficulty(d, lo, hi Difficulty) Difficulty {
+	return Difficulty{
+		HiddenPositions: clampInt(d.HiddenPositions, lo.HiddenPositions, hi.HiddenPositions),
+		MinWordLength:   clampInt(d.MinWordLength, lo.MinWordLength, hi.MinWordLength),
+		ExtraLetters:    clampInt(d.ExtraLetters, lo.ExtraLetters, hi.ExtraLetters),
+	}
+}
+
+func clampInt(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
+
+func clampDuration(v, lo, hi time.Duration) time.Duration {
+	return max(lo, min(v, hi))
+}
+
+// puzzleSettings is the admin view of a single puzzle
+type puzzleSettings struct {
+	Name             string      `json:"name"`
+	TimeLimitSeconds float64     `json:"time_limit_seconds"`
+	Difficulty       *Difficulty `json:"difficulty,omitempty"`
+	Total            int         `json:"total"`
+	Solved           int         `json:"solved"`
+	TooFast          int         `json:"too_fast"`
+	Normalized       int         `json:"normalized"`
+	WindowSamples    int         `json:"window_samples"`
+	WindowSuccess    float64     `json:"window_success_rate"`
+	WindowLatency    float64     `json:"window_mean_latency_seconds"`
+	Adjustments      int         `json:"adjustments"`
+}
+
+// AdminHandler returns an HTTP handler that reports the current difficulty,
+// time limits and rolling statistics of every registered puzzle as JSON
+func (c *BotchaMiddleware) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.statsMu.Lock()
+		settings := make([]puzzleSettings, 0, len(c.puzzleNames))
+		for _, name := range c.puzzleNames {
+			s := c.statsFor(name)
+			rate, latency := windowSummary(s.window)
+			ps := puzzleSettings{
+				Name:             name,
+				TimeLimitSeconds: s.timeLimit.Seconds(),
+				Total:            s.total,
+				Solved:           s.solved,
+				TooFast:          s.tooFast,
+				Normalized:       s.normalized,
+				WindowSamples:    len(s.window),
+				WindowSuccess:    rate,
+				WindowLatency:    latency.Seconds(),
+				Adjustments:      s.adjustments,
+			}
+			if t, ok := c.puzzles[name].(Tunable); ok {
+				d := t.Difficulty()
+				ps.Difficulty = &d
+			}
+			settings = append(settings, ps)
+		}
+		c.statsMu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(struct {
+			Adaptive bool             `json:"adaptive"`
+			Puzzles  []puzzleSettings `json:"puzzles"`
+		}{c.adaptive != nil, settings})
+	})
+}
@@ -0,0 +1,251 @@
+package challenge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tunablePuzzle is a testPuzzle whose difficulty the adaptive loop can change
+type tunablePuzzle struct {
+	testPuzzle
+	mu sync.Mutex
+	d  Difficulty
+}
+
+func (p *tunablePuzzle) Difficulty() Difficulty {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.d
+}
+
+func (p *tunablePuzzle) SetDifficulty(d Difficulty) {
+	p.mu.Lock()
+	p.d = d
+	p.mu.Unlock()
+}
+
+// record adds n outcomes of one kind from addr to the statistics of a puzzle
+func record(c *BotchaMiddleware, puzzleName, addr string, kind outcomeKind, n int) {
+	client := ClientInfo{Addr: netip.MustParseAddr(addr)}
+	for range n {
+		c.recordOutcome(puzzleName, client, kind, time.Second)
+	}
+}
+
+func TestHarderAndEasierOrder(t *testing.T) {
+	cfg := DefaultAdaptiveConfig()
+
+	// Harder raises the decoy letters, then the hidden positions, then the
+	// word length, one step at a time
+	steps := []Difficulty{cfg.MinDifficulty}
+	for {
+		d, ok := harder(steps[len(steps)-1], cfg.MaxDifficulty)
+		if !ok {
+			break
+		}
+		steps = append(steps, d)
+	}
+	if last := steps[len(steps)-1]; last != cfg.MaxDifficulty {
+		t.Fatalf("harder stopped at %+v, want %+v", last, cfg.MaxDifficulty)
+	}
+	for i := 1; i < len(steps); i++ {
+		prev, d := steps[i-1], steps[i]
+		var want Difficulty
+		switch {
+		case prev.ExtraLetters < cfg.MaxDifficulty.ExtraLetters:
+			want = prev
+			want.ExtraLetters++
+		case prev.HiddenPositions < cfg.MaxDifficulty.HiddenPositions:
+			want = prev
+			want.HiddenPositions++
+		default:
+			want = prev
+			want.MinWordLength++
+		}
+		if d != want {
+			t.Fatalf("step %d: got %+v, want %+v", i, d, want)
+		}
+	}
+
+	// Easier retraces the same steps in reverse
+	d := cfg.MaxDifficulty
+	for i := len(steps) - 2; i >= 0; i-- {
+		var ok bool
+		d, ok = easier(d, cfg.MinDifficulty)
+		if !ok || d != steps[i] {
+			t.Fatalf("easier step to %d: got %+v (%v), want %+v", i, d, ok, steps[i])
+		}
+	}
+	if _, ok := easier(d, cfg.MinDifficulty); ok {
+		t.Error("easier went below the minimum difficulty")
+	}
+}
+
+func TestEnableAdaptiveClampsDifficulty(t *testing.T) {
+	p := &tunablePuzzle{testPuzzle: testPuzzle{"tunable", "apple"}}
+	c := newTestMiddleware(p)
+	cfg := DefaultAdaptiveConfig()
+	c.EnableAdaptive(cfg)
+
+	if got := p.Difficulty(); got != cfg.MinDifficulty {
+		t.Errorf("got %+v, want the minimum %+v", got, cfg.MinDifficulty)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	cfg := DefaultAdaptiveConfig()
+	cfg.Window = 4
+	cfg.MaxPerClient = 4
+	p := &tunablePuzzle{testPuzzle: testPuzzle{"tunable", "apple"}, d: cfg.MinDifficulty}
+	c := newTestMiddleware(p)
+	c.EnableAdaptive(cfg)
+
+	// Fast solves make the puzzle harder
+	record(c, "tunable", "192.0.2.1", outcomeSolved, 4)
+	want := cfg.MinDifficulty
+	want.ExtraLetters++
+	if got := p.Difficulty(); got != want {
+		t.Fatalf("after fast solves: got %+v, want %+v", got, want)
+	}
+
+	// Wrong answers make it easier again
+	record(c, "tunable", "192.0.2.1", outcomeWrong, 4)
+	if got := p.Difficulty(); got != cfg.MinDifficulty {
+		t.Fatalf("after wrong answers: got %+v, want %+v", got, cfg.MinDifficulty)
+	}
+
+	// Expired sessions buy more time instead
+	record(c, "tunable", "192.0.2.1", outcomeExpired, 4)
+	if got := c.timeLimitFor("tunable"); got != sessionTimeout+cfg.TimeLimitStep {
+		t.Fatalf("after expired sessions: time limit %s, want %s", got, sessionTimeout+cfg.TimeLimitStep)
+	}
+
+	// At the minimum difficulty, wrong answers also lengthen the time limit
+	record(c, "tunable", "192.0.2.1", outcomeWrong, 4)
+	if got := c.timeLimitFor("tunable"); got != sessionTimeout+2*cfg.TimeLimitStep {
+		t.Errorf("at the minimum difficulty: time limit %s, want %s", got, sessionTimeout+2*cfg.TimeLimitStep)
+	}
+	if got := p.Difficulty(); got != cfg.MinDifficulty {
+		t.Errorf("difficulty left the minimum: %+v", got)
+	}
+}
+
+func TestTimeLimitStaysWithinBounds(t *testing.T) {
+	cfg := DefaultAdaptiveConfig()
+	cfg.Window = 4
+	cfg.MaxPerClient = 4
+	cfg.MinTimeLimit = 10 * time.Second
+	cfg.MaxTimeLimit = 20 * time.Second
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.EnableAdaptive(cfg)
+
+	// The 30 second default is clamped to the configured maximum
+	if got := c.timeLimitFor("test"); got != cfg.MaxTimeLimit {
+		t.Fatalf("after EnableAdaptive: got %s, want %s", got, cfg.MaxTimeLimit)
+	}
+
+	// Without a difficulty to raise, fast solves shorten the time limit down
+	// to the minimum and no further
+	for range 5 {
+		record(c, "test", "192.0.2.1", outcomeSolved, 4)
+	}
+	if got := c.timeLimitFor("test"); got != cfg.MinTimeLimit {
+		t.Fatalf("after fast solves: got %s, want %s", got, cfg.MinTimeLimit)
+	}
+
+	for range 5 {
+		record(c, "test", "192.0.2.1", outcomeExpired, 4)
+	}
+	if got := c.timeLimitFor("test"); got != cfg.MaxTimeLimit {
+		t.Errorf("after expired sessions: got %s, want %s", got, cfg.MaxTimeLimit)
+	}
+}
+
+func TestMaxPerClient(t *testing.T) {
+	cfg := DefaultAdaptiveConfig()
+	cfg.Window = 100
+	cfg.MaxPerClient = 2
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.EnableAdaptive(cfg)
+
+	tests := []struct {
+		addr string
+		want int
+	}{
+		{"192.0.2.1", 1},
+		{"192.0.2.200", 2},
+		// Third outcome from the same /24
+		{"192.0.2.7", 2},
+		{"192.0.3.1", 3},
+		{"2001:db8::1", 4},
+		{"2001:db8::ffff:1", 5},
+		// Third outcome from the same /64
+		{"2001:db8::2", 5},
+		{"2001:db8:0:1::1", 6},
+	}
+	for i, tt := range tests {
+		record(c, "test", tt.addr, outcomeWrong, 1)
+		s := c.stats["test"]
+		if len(s.window) != tt.want {
+			t.Errorf("after %s: %d outcomes in the window, want %d", tt.addr, len(s.window), tt.want)
+		}
+		if s.total != i+1 {
+			t.Errorf("after %s: total %d, want %d", tt.addr, s.total, i+1)
+		}
+	}
+}
+
+func TestAdminHandler(t *testing.T) {
+	p := &tunablePuzzle{testPuzzle: testPuzzle{"tunable", "apple"}}
+	c := newTestMiddleware(p, testPuzzle{"plain", "pear"})
+	c.EnableAdaptive(DefaultAdaptiveConfig())
+	record(c, "tunable", "192.0.2.1", outcomeSolved, 1)
+	c.recordTooFast("plain")
+
+	rec := httptest.NewRecorder()
+	c.AdminHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/botcha", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type %q", ct)
+	}
+
+	var got struct {
+		Adaptive bool `json:"adaptive"`
+		Puzzles  []struct {
+			Name          string      `json:"name"`
+			TimeLimit     float64     `json:"time_limit_seconds"`
+			Difficulty    *Difficulty `json:"difficulty"`
+			Total         int         `json:"total"`
+			Solved        int         `json:"solved"`
+			TooFast       int         `json:"too_fast"`
+			WindowSamples int         `json:"window_samples"`
+			WindowSuccess float64     `json:"window_success_rate"`
+			WindowLatency float64     `json:"window_mean_latency_seconds"`
+		} `json:"puzzles"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", rec.Body, err)
+	}
+	if !got.Adaptive || len(got.Puzzles) != 2 {
+		t.Fatalf("got %s", rec.Body)
+	}
+
+	tunable, plain := got.Puzzles[0], got.Puzzles[1]
+	if tunable.Name != "tunable" || plain.Name != "plain" {
+		t.Errorf("puzzles out of registration order: %s", rec.Body)
+	}
+	if tunable.Difficulty == nil || *tunable.Difficulty != DefaultAdaptiveConfig().MinDifficulty {
+		t.Errorf("tunable difficulty: got %+v", tunable.Difficulty)
+	}
+	if tunable.TimeLimit != sessionTimeout.Seconds() || tunable.Total != 1 || tunable.Solved != 1 ||
+		tunable.WindowSamples != 1 || tunable.WindowSuccess != 1 || tunable.WindowLatency != 1 {
+		t.Errorf("tunable statistics: got %+v", tunable)
+	}
+	if plain.Difficulty != nil || plain.TooFast != 1 || plain.Total != 0 {
+		t.Errorf("plain puzzle: got %+v", plain)
+	}
+}
@@ -0,0 +1,85 @@
+package challenge
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAttempts(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.SetMaxAttempts(3)
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	for left := 2; left > 0; left-- {
+		body, reason := defaultClient.answer(h, id, "pear")
+		if reason != ReasonIncorrect || !strings.Contains(body, fmt.Sprintf("Attempts remaining: %d", left)) {
+			t.Fatalf("wrong answer with %d left: got %q, reason %q", left, body, reason)
+		}
+	}
+	if body, _ := defaultClient.answer(h, id, "apple"); body != "protected content" {
+		t.Errorf("last attempt: got %q", body)
+	}
+
+	// Using up every attempt ends the session
+	id = defaultClient.challenge(t, h)
+	for range 3 {
+		defaultClient.answer(h, id, "pear")
+	}
+	if _, reason := defaultClient.answer(h, id, "apple"); reason != ReasonInvalidSession {
+		t.Errorf("after the last attempt: got reason %q, want %q", reason, ReasonInvalidSession)
+	}
+}
+
+func TestParallelSubmissions(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		answer   string
+	}{
+		{"correct", 1, "apple"},
+		{"correct with retries", 3, "apple"},
+		{"wrong", 3, "pear"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestMiddleware(testPuzzle{"test", "apple"})
+			c.SetMaxAttempts(tt.attempts)
+			h := c.Middleware(protected)
+			id := defaultClient.challenge(t, h)
+
+			var mu sync.Mutex
+			reasons := make(map[string]int)
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					body, reason := defaultClient.answer(h, id, tt.answer)
+					mu.Lock()
+					defer mu.Unlock()
+					if body == "protected content" {
+						reasons["passed"]++
+					} else {
+						reasons[string(reason)]++
+					}
+				}()
+			}
+			wg.Wait()
+
+			// A correct answer is redeemed once; wrong answers use up the attempts
+			want := map[string]int{"passed": 1, string(ReasonInvalidSession): 19}
+			if tt.answer != "apple" {
+				want = map[string]int{string(ReasonIncorrect): tt.attempts, string(ReasonInvalidSession): 20 - tt.attempts}
+			}
+			for k, n := range want {
+				if reasons[k] != n {
+					t.Errorf("got %v, want %v", reasons, want)
+					break
+				}
+			}
+		})
+	}
+}
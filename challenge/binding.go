@@ -0,0 +1,88 @@
+package challenge
+
+import (
+	"log"
+	"net/http"
+	"net/netip"
+)
+
+// Binding selects which attributes of the issuing request a session is tied to.
+// The zero value binds nothing, so any client may redeem a session.
+type Binding struct {
+	// IPv4Prefix and IPv6Prefix are the number of leading address bits that
+	// must match between the issuing and answering requests; 0 disables the check
+	IPv4Prefix int
+	IPv6Prefix int
+	// UserAgent requires an identical User-Agent header
+	UserAgent bool
+	// Nonce requires the answer to repeat the nonce query parameter sent with
+	// the challenge request, if the client sent one
+	Nonce bool
+}
+
+// ClientInfo holds the attributes of a request that a session can be bound to
+type ClientInfo struct {
+	Addr      netip.Addr
+	UserAgent string
+	Nonce     string
+}
+
+// SetBinding ties every new session to the client that requested it.
+// Prefixes longer than the address are clamped to the full address.
+// Must be called before the middleware starts serving requests.
+func (c *BotchaMiddleware) SetBinding(b Binding) {
+	b.IPv4Prefix = min(b.IPv4Prefix, 32)
+	b.IPv6Prefix = min(b.IPv6Prefix, 128)
+	c.binding = b
+	log.Printf("Session binding: IPv4 /%d, IPv6 /%d, user agent %t, nonce %t",
+		b.IPv4Prefix, b.IPv6Prefix, b.UserAgent, b.Nonce)
+}
+
+// clientInfoFromRequest extracts the bindable attributes of a request
+func clientInfoFromRequest(r *http.Request) ClientInfo {
+	var addr netip.Addr
+	if ap, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		addr = ap.Addr().Unmap()
+	} else if a, err := netip.ParseAddr(r.RemoteAddr); err == nil {
+		addr = a.Unmap()
+	}
+	return ClientInfo{
+		Addr:      addr,
+		UserAgent: r.UserAgent(),
+		Nonce:     r.URL.Query().Get("nonce"),
+	}
+}
+
+// mismatch returns the name of the first bound attribute that differs
+// between the issuing and the answering client, or "" if they match
+func (b Binding) mismatch(issued, current ClientInfo) string {
+	if !b.sameNetwork(issued.Addr, current.Addr) {
+		return "IP address"
+	}
+	if b.UserAgent && issued.UserAgent != current.UserAgent {
+		return "user agent"
+	}
+	if b.Nonce && issued.Nonce != "" && issued.Nonce != current.Nonce {
+		return "nonce"
+	}
+	return ""
+}
+
+// sameNetwork reports whether both addresses fall into the same bound prefix
+func (b Binding) sameNetwork(issued, current netip.Addr) bool {
+	bits := b.IPv6Prefix
+	if issued.Is4() {
+		bits = b.IPv4Prefix
+	}
+	if bits <= 0 {
+		return true
+	}
+	if !issued.IsValid() || !current.IsValid() || issued.Is4() != current.Is4() {
+		return false
+	}
+	issuedPrefix, err := issued.Prefix(bits)
+	if err != nil {
+		return false
+	}
+	return issuedPrefix.Contains(current)
+}
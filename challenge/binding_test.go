@@ -0,0 +1,73 @@
+package challenge
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestBindingMismatch(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.SetBinding(Binding{IPv4Prefix: 24, UserAgent: true})
+	h := c.Middleware(protected)
+
+	tests := []struct {
+		name   string
+		client testClient
+		want   Reason
+	}{
+		{"other network", testClient{"192.0.3.10:4000", defaultClient.userAgent}, ReasonClientMismatch},
+		{"other user agent", testClient{defaultClient.addr, "script/2.0"}, ReasonClientMismatch},
+		{"IPv6 client", testClient{"[2001:db8::1]:4000", defaultClient.userAgent}, ReasonClientMismatch},
+		{"same network", testClient{"192.0.2.99:5000", defaultClient.userAgent}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := defaultClient.challenge(t, h)
+			body, reason := tt.client.answer(h, id, "apple")
+			if reason != tt.want {
+				t.Fatalf("got %q, reason %q; want reason %q", body, reason, tt.want)
+			}
+			// A mismatch keeps the session for the client it was issued to
+			if reason == ReasonClientMismatch {
+				if body, _ := defaultClient.answer(h, id, "apple"); body != "protected content" {
+					t.Errorf("issuing client after a mismatch: got %q", body)
+				}
+			}
+		})
+	}
+}
+
+func TestBindingNonce(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.SetBinding(Binding{Nonce: true})
+	h := c.Middleware(protected)
+
+	body, _ := defaultClient.get(h, map[string][]string{"nonce": {"n1"}})
+	m := sessionParam.FindStringSubmatch(body)
+	if m == nil {
+		t.Fatalf("no session in challenge:\n%s", body)
+	}
+	if _, reason := defaultClient.answer(h, m[1], "apple"); reason != ReasonClientMismatch {
+		t.Errorf("answer without nonce: got reason %q, want %q", reason, ReasonClientMismatch)
+	}
+	body, _ = defaultClient.get(h, map[string][]string{"session": {m[1]}, "answer": {"apple"}, "nonce": {"n1"}})
+	if body != "protected content" {
+		t.Errorf("answer with nonce: got %q", body)
+	}
+}
+
+func TestSetBindingClampsPrefixes(t *testing.T) {
+	c := New()
+	c.SetBinding(Binding{IPv4Prefix: 64, IPv6Prefix: 200})
+	if c.binding.IPv4Prefix != 32 || c.binding.IPv6Prefix != 128 {
+		t.Fatalf("got /%d and /%d, want /32 and /128", c.binding.IPv4Prefix, c.binding.IPv6Prefix)
+	}
+
+	addr := netip.MustParseAddr("192.0.2.10")
+	if !c.binding.sameNetwork(addr, addr) {
+		t.Error("address does not match itself after clamping")
+	}
+	if c.binding.sameNetwork(addr, netip.MustParseAddr("192.0.2.11")) {
+		t.Error("neighbouring address matches a /32 binding")
+	}
+}
@@ -0,0 +1,91 @@
+package challenge
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Chain requires solving several puzzles in a row before the protected content is served
+type Chain struct {
+	// Rounds names the puzzle of each round; an empty name picks a random registered puzzle
+	Rounds []string
+	// RoundTimeLimit overrides the puzzle time limit of every round; 0 keeps the puzzle's own
+	RoundTimeLimit time.Duration
+	// Deadline bounds the whole chain from the first challenge; 0 means no overall deadline
+	Deadline time.Duration
+}
+
+// ChainMiddleware returns an HTTP middleware that guards the next handler with
+// a chain of challenges. Each solved round issues the next challenge within the
+// same session. Panics if a round names a puzzle that is not registered.
+func (c *BotchaMiddleware) ChainMiddleware(chain Chain, next http.Handler) http.Handler {
+	if len(chain.Rounds) == 0 {
+		panic("challenge: chain has no rounds")
+	}
+	for _, name := range chain.Rounds {
+		if _, ok := c.puzzles[name]; name != "" && !ok {
+			panic("challenge: chain uses unregistered puzzle " + name)
+		}
+	}
+	log.Printf("Registered chain of %d rounds: %v", len(chain.Rounds), chain.Rounds)
+	return c.guard(&chain, next)
+}
+
+// roundTimeLimit returns the time limit of the next round, bounded by the chain deadline
+func (c *BotchaMiddleware) roundTimeLimit(chain *Chain, puzzleName string, chainStartedAt time.Time) time.Duration {
+	limit := c.timeLimitFor(puzzleName)
+	if chain == nil {
+		return limit
+	}
+	if chain.RoundTimeLimit > 0 {
+		limit = chain.RoundTimeLimit
+	}
+	if chain.Deadline > 0 {
+		limit = min(limit, chain.Deadline-time.Since(chainStartedAt))
+	}
+	return limit
+}
+
+// advanceChain returns the next round of a solved chain session along with
+// the text of the new challenge. It fails if the chain deadline leaves less
+// than a second, or less than the minimum solve time, for the next round.
+func (c *BotchaMiddleware) advanceChain(sessionID string, session Session) (Session, string, bool) {
+	round := session.Round + 1
+	puzzleName := c.pickPuzzle(session.Chain, round)
+	timeLimit := c.roundTimeLimit(session.Chain, puzzleName, session.ChainStartedAt)
+	if timeLimit < max(time.Second, c.minSolveTimeFor(puzzleName)) {
+		return session, "", false
+	}
+	instructions, state := c.puzzles[puzzleName].Generate()
+
+	session.PuzzleName = puzzleName
+	session.State = state
+	session.CreatedAt = time.Now()
+	session.TimeLimit = timeLimit
+	session.AttemptsLeft = c.maxAttempts
+	session.Round = round
+
+	return session, fmt.Sprintf(`CORRECT - Round %d of %d solved
+
+Round %d of %d:
+
+%s
+
+Submit answer within %d seconds: %s&answer=<word>
+`, round, len(session.Chain.Rounds), round+1, len(session.Chain.Rounds),
+		instructions, int(timeLimit.Seconds()), c.submitQuery(sessionID, session.Client)), true
+}
+
+// chainIntro describes a chain at the top of its first challenge
+func chainIntro(chain *Chain) string {
+	if chain == nil {
+		return ""
+	}
+	intro := fmt.Sprintf("This content requires solving %d puzzles in a row.", len(chain.Rounds))
+	if chain.Deadline > 0 {
+		intro += fmt.Sprintf(" All of them must be solved within %d seconds.", int(chain.Deadline.Seconds()))
+	}
+	return intro + fmt.Sprintf("\n\nRound 1 of %d:\n\n", len(chain.Rounds))
+}
@@ -0,0 +1,99 @@
+package challenge
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChainRounds(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"first", "apple"}, testPuzzle{"second", "pear"})
+	h := c.ChainMiddleware(Chain{Rounds: []string{"first", "second"}}, protected)
+
+	id := defaultClient.challenge(t, h)
+	body, reason := defaultClient.answer(h, id, "apple")
+	if reason != "" || !strings.Contains(body, "Round 1 of 2 solved") || !strings.Contains(body, "Answer with pear.") {
+		t.Fatalf("first round: got %q, reason %q", body, reason)
+	}
+	// The solved round's answer cannot be replayed
+	if _, reason := defaultClient.answer(h, id, "apple"); reason != ReasonIncorrect {
+		t.Fatalf("replayed first answer: got reason %q, want %q", reason, ReasonIncorrect)
+	}
+
+	id = defaultClient.challenge(t, h)
+	defaultClient.answer(h, id, "apple")
+	if body, _ := defaultClient.answer(h, id, "pear"); body != "protected content" {
+		t.Errorf("last round: got %q", body)
+	}
+	if _, reason := defaultClient.answer(h, id, "pear"); reason != ReasonInvalidSession {
+		t.Errorf("finished chain: got reason %q, want %q", reason, ReasonInvalidSession)
+	}
+}
+
+func TestChainSessionsStayAtTheirEndpoint(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"first", "apple"})
+	single := c.Middleware(protected)
+	chain := c.ChainMiddleware(Chain{Rounds: []string{"first", "first"}}, protected)
+
+	id := defaultClient.challenge(t, single)
+	if _, reason := defaultClient.answer(chain, id, "apple"); reason != ReasonInvalidSession {
+		t.Errorf("single session at the chain: got reason %q, want %q", reason, ReasonInvalidSession)
+	}
+	id = defaultClient.challenge(t, chain)
+	if body, reason := defaultClient.answer(single, id, "apple"); reason != ReasonInvalidSession {
+		t.Errorf("chain session at the single endpoint: got %q, reason %q", body, reason)
+	}
+}
+
+func TestChainRejectsUnregisteredPuzzle(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"first", "apple"})
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic for an unregistered puzzle")
+		}
+	}()
+	c.ChainMiddleware(Chain{Rounds: []string{"first", "missing"}}, protected)
+}
+
+func TestChainDeadline(t *testing.T) {
+	tests := []struct {
+		name         string
+		left         time.Duration
+		minSolveTime time.Duration
+		want         Reason
+	}{
+		{"time left", 5 * time.Second, 0, ""},
+		{"under a second left", 500 * time.Millisecond, 0, ReasonExpired},
+		{"under the minimum solve time left", 2500 * time.Millisecond, 3 * time.Second, ReasonExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestMiddleware(testPuzzle{"first", "apple"}, testPuzzle{"second", "pear"})
+			c.SetLatencyProfile("second", LatencyProfile{MinSolveTime: tt.minSolveTime})
+			chain := Chain{Rounds: []string{"first", "second"}, Deadline: 10 * time.Second}
+			h := c.ChainMiddleware(chain, protected)
+
+			id := defaultClient.challenge(t, h)
+			session := c.sessions[id]
+			session.ChainStartedAt = session.ChainStartedAt.Add(tt.left - chain.Deadline)
+			c.sessions[id] = session
+
+			body, reason := defaultClient.answer(h, id, "apple")
+			if reason != tt.want {
+				t.Fatalf("got %q, reason %q; want reason %q", body, reason, tt.want)
+			}
+			if tt.want == "" && !strings.Contains(body, "Answer with pear.") {
+				t.Fatalf("no second round in %q", body)
+			}
+			if tt.want == ReasonExpired {
+				if _, reason := defaultClient.answer(h, id, "pear"); reason != ReasonInvalidSession {
+					t.Errorf("ended chain still usable: reason %q", reason)
+				}
+				// The solved round still counts as a solve
+				if s := c.stats["first"]; s.solved != 1 {
+					t.Errorf("got %d solves of the first round, want 1", s.solved)
+				}
+			}
+		})
+	}
+}
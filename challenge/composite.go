@@ -0,0 +1,117 @@
+package challenge
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompositeMode decides how the two parts of a composite puzzle relate
+type CompositeMode int
+
+const (
+	// CompositeBoth asks both puzzles and requires both answers, separated by a delimiter
+	CompositeBoth CompositeMode = iota
+	// CompositeFeed uses the answer of the first puzzle as the key of the second;
+	// only the second answer is submitted
+	CompositeFeed
+)
+
+// defaultCompositeDelimiter separates the two answers in CompositeBoth mode
+const defaultCompositeDelimiter = "|"
+
+// CompositePuzzle combines two puzzles into a single challenge using their
+// existing Generate and Validate methods. It does not implement Tunable, so
+// adaptive difficulty only changes the time limit of a composite; the parts
+// keep the difficulty they were created with.
+type CompositePuzzle struct {
+	first, second Puzzle
+	mode          CompositeMode
+	delimiter     string
+}
+
+// compositeState holds the states of both parts
+type compositeState struct {
+	First  any
+	Second any
+}
+
+// NewCompositePuzzle creates a puzzle that requires the answers of both puzzles,
+// separated by delimiter ("|" if empty)
+func NewCompositePuzzle(first, second Puzzle, delimiter string) *CompositePuzzle {
+	if delimiter == "" {
+		delimiter = defaultCompositeDelimiter
+	}
+	return &CompositePuzzle{first: first, second: second, mode: CompositeBoth, delimiter: delimiter}
+}
+
+// NewFeedCompositePuzzle creates a puzzle whose first answer becomes the key of
+// the second. Panics if first does not implement Answerer or second does not
+// implement KeyedGenerator.
+func NewFeedCompositePuzzle(first, second Puzzle) *CompositePuzzle {
+	if _, ok := first.(Answerer); !ok {
+		panic("challenge: " + first.Name() + " cannot report its answer")
+	}
+	if _, ok := second.(KeyedGenerator); !ok {
+		panic("challenge: " + second.Name() + " cannot generate from a key")
+	}
+	return &CompositePuzzle{first: first, second: second, mode: CompositeFeed}
+}
+
+// Name returns the puzzle identifier, built from the names of both parts
+func (p *CompositePuzzle) Name() string {
+	if p.mode == CompositeFeed {
+		return p.first.Name() + ">" + p.second.Name()
+	}
+	return p.first.Name() + "+" + p.second.Name()
+}
+
+// Generate creates both parts and joins their instructions
+func (p *CompositePuzzle) Generate() (instructions string, state any) {
+	firstInstructions, firstState := p.first.Generate()
+
+	if p.mode == CompositeFeed {
+		key := p.first.(Answerer).Answer(firstState)
+		secondInstructions, secondState := p.second.(KeyedGenerator).GenerateWithKey(key)
+		instructions = fmt.Sprintf(`This challenge has two parts. The answer to part 1 is the key to part 2.
+Submit only the answer to part 2.
+
+PART 1
+
+%s
+
+PART 2
+
+%s`, firstInstructions, secondInstructions)
+		return instructions, compositeState{First: firstState, Second: secondState}
+	}
+
+	secondInstructions, secondState := p.second.Generate()
+	instructions = fmt.Sprintf(`This challenge has two parts. Solve both and submit the answers
+separated by "%[1]s", part 1 first: <answer 1>%[1]s<answer 2>
+
+PART 1
+
+%[2]s
+
+PART 2
+
+%[3]s`, p.delimiter, firstInstructions, secondInstructions)
+	return instructions, compositeState{First: firstState, Second: secondState}
+}
+
+// Validate checks the answer of each part with the wrapped puzzles
+func (p *CompositePuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(compositeState)
+	if !ok {
+		return false
+	}
+	if p.mode == CompositeFeed {
+		return p.second.Validate(s.Second, answer)
+	}
+	firstAnswer, secondAnswer, found := strings.Cut(answer, p.delimiter)
+	if !found {
+		return false
+	}
+	return p.first.Validate(s.First, strings.TrimSpace(firstAnswer)) &&
+		p.second.Validate(s.Second, strings.TrimSpace(secondAnswer))
+}
@@ -0,0 +1,67 @@
+package challenge
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompositeBoth(t *testing.T) {
+	p := NewCompositePuzzle(testPuzzle{"first", "apple"}, testPuzzle{"second", "pear"}, "")
+	if p.Name() != "first+second" {
+		t.Errorf("got name %q", p.Name())
+	}
+	instructions, state := p.Generate()
+	if !strings.Contains(instructions, "Answer with apple.") || !strings.Contains(instructions, "Answer with pear.") {
+		t.Fatalf("instructions lack a part:\n%s", instructions)
+	}
+
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"apple|pear", true},
+		{"apple | pear", true},
+		{"pear|apple", false},
+		{"apple", false},
+		{"apple|", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestCompositeFeed(t *testing.T) {
+	p := NewFeedCompositePuzzle(testPuzzle{"first", "apple"}, testPuzzle{"second", "pear"})
+	if p.Name() != "first>second" {
+		t.Errorf("got name %q", p.Name())
+	}
+	_, state := p.Generate()
+	// The second part is keyed with the first part's answer
+	if !p.Validate(state, "applepear") {
+		t.Error("keyed answer rejected")
+	}
+	if p.Validate(state, "pear") || p.Validate(state, "apple|applepear") {
+		t.Error("answer without the key accepted")
+	}
+}
+
+func TestCompositeThroughMiddleware(t *testing.T) {
+	c := newTestMiddleware(NewCompositePuzzle(testPuzzle{"first", "apple"}, testPuzzle{"second", "pear"}, ";"))
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	if body, _ := defaultClient.answer(h, id, "apple;pear"); body != "protected content" {
+		t.Errorf("got %q", body)
+	}
+}
+
+func TestFeedCompositeRequiresKeyedPuzzle(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic for a puzzle without GenerateWithKey")
+		}
+	}()
+	NewFeedCompositePuzzle(testPuzzle{"first", "apple"}, NewCompositePuzzle(testPuzzle{"a", "b"}, testPuzzle{"c", "d"}, ""))
+}
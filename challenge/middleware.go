@@ -12,13 +12,44 @@ import (
 	"github.com/google/uuid"
 )
 
+// sessionTimeout is the default time limit for solving a challenge
 const sessionTimeout = 30 * time.Second
 
+// defaultMaxAttempts is the default number of answers accepted per session
+const defaultMaxAttempts = 1
+
+// invalidSessionMessage is shown for unknown, used up and already redeemed sessions
+const invalidSessionMessage = `VERIFICATION FAILED - Invalid Session
+
+The session ID you provided does not exist or has already been used.
+
+To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`
+
+// Reason identifies why a submission failed or was flagged
+type Reason string
+
+const (
+	ReasonInvalidSession Reason = "invalid_session"
+	ReasonClientMismatch Reason = "client_mismatch"
+	ReasonExpired        Reason = "expired"
+	ReasonTooFast        Reason = "too_fast"
+	ReasonIncorrect      Reason = "incorrect"
+	ReasonInternal       Reason = "internal_error"
+)
+
 // Session holds the state for an active challenge
 type Session struct {
-	PuzzleName string
-	State      any
-	CreatedAt  time.Time
+	PuzzleName   string
+	State        any
+	CreatedAt    time.Time
+	TimeLimit    time.Duration
+	Client       ClientInfo
+	AttemptsLeft int
+
+	// Chain is set for multi-round sessions issued by ChainMiddleware
+	Chain          *Chain
+	Round          int
+	ChainStartedAt time.Time
 }
 
 // BotchaMiddleware manages puzzle registration, sessions, and validation
@@ -28,18 +59,42 @@ type BotchaMiddleware struct {
 	sessions     map[string]Session
 	sessionOrder []string
 	sessionsMu   sync.RWMutex
+
+	adaptive *AdaptiveConfig
+	stats    map[string]*puzzleStats
+	statsMu  sync.Mutex
+
+	minSolveTime    time.Duration
+	tooFastAction   TooFastAction
+	latencyProfiles map[string]LatencyProfile
+
+	binding     Binding
+	maxAttempts int
+	normalizers []Normalizer
 }
 
 // New creates a new BotchaMiddleware instance
 func New() *BotchaMiddleware {
 	return &BotchaMiddleware{
-		puzzles:      make(map[string]Puzzle),
-		puzzleNames:  []string{},
-		sessions:     make(map[string]Session),
-		sessionOrder: []string{},
+		puzzles:         make(map[string]Puzzle),
+		puzzleNames:     []string{},
+		sessions:        make(map[string]Session),
+		sessionOrder:    []string{},
+		stats:           make(map[string]*puzzleStats),
+		latencyProfiles: make(map[string]LatencyProfile),
+		maxAttempts:     defaultMaxAttempts,
+		normalizers:     DefaultNormalizers(),
 	}
 }
 
+// SetMaxAttempts sets how many answers may be submitted per session.
+// A wrong answer uses up an attempt; the last one invalidates the session.
+// Must be called before the middleware starts serving requests.
+func (c *BotchaMiddleware) SetMaxAttempts(n int) {
+	c.maxAttempts = max(n, 1)
+	log.Printf("Maximum attempts per session: %d", c.maxAttempts)
+}
+
 // RegisterPuzzle adds a puzzle to the registry
 func (c *BotchaMiddleware) RegisterPuzzle(p Puzzle) {
 	name := p.Name()
@@ -48,14 +103,14 @@ func (c *BotchaMiddleware) RegisterPuzzle(p Puzzle) {
 	log.Printf("Registered puzzle: %s", name)
 }
 
-// evictExpiredSessions removes sessions older than the timeout
+// evictExpiredSessions removes sessions older than their time limit
 // Must be called with sessionsMu held
 func (c *BotchaMiddleware) evictExpiredSessions() {
 	now := time.Now()
 	newOrder := c.sessionOrder[:0]
 	for _, id := range c.sessionOrder {
 		if session, exists := c.sessions[id]; exists {
-			if now.Sub(session.CreatedAt) > sessionTimeout {
+			if now.Sub(session.CreatedAt) > session.TimeLimit {
 				delete(c.sessions, id)
 				log.Printf("Evicted expired session: %s", id)
 			} else {
@@ -66,18 +121,30 @@ func (c *BotchaMiddleware) evictExpiredSessions() {
 	c.sessionOrder = newOrder
 }
 
-// generateChallenge picks a random puzzle, generates a challenge, and creates a session
-func (c *BotchaMiddleware) generateChallenge() (sessionID string, instructions string) {
+// pickPuzzle returns the name of the puzzle for a round of the chain.
+// Without a chain, or for an unnamed round, a random puzzle is picked.
+func (c *BotchaMiddleware) pickPuzzle(chain *Chain, round int) string {
+	if chain != nil && chain.Rounds[round] != "" {
+		return chain.Rounds[round]
+	}
+	return c.puzzleNames[rand.Intn(len(c.puzzleNames))]
+}
+
+// generateChallenge picks a puzzle, generates a challenge, and creates a session
+// bound to the requesting client. With a chain, the first round's puzzle is used.
+func (c *BotchaMiddleware) generateChallenge(client ClientInfo, chain *Chain) (sessionID string, instructions string, timeLimit time.Duration) {
 	if len(c.puzzleNames) == 0 {
-		return "", "No puzzles registered"
+		return "", "No puzzles registered", sessionTimeout
 	}
 
-	// Pick a random puzzle
-	puzzleName := c.puzzleNames[rand.Intn(len(c.puzzleNames))]
+	// Pick a puzzle
+	puzzleName := c.pickPuzzle(chain, 0)
 	puzzle := c.puzzles[puzzleName]
 
 	// Generate the challenge
 	instructions, state := puzzle.Generate()
+	now := time.Now()
+	timeLimit = c.roundTimeLimit(chain, puzzleName, now)
 
 	// Create session ID (last 6 chars of UUID)
 	fullUUID := uuid.New().String()
@@ -87,108 +154,283 @@ func (c *BotchaMiddleware) generateChallenge() (sessionID string, instructions s
 	c.sessionsMu.Lock()
 	c.evictExpiredSessions()
 	c.sessions[sessionID] = Session{
-		PuzzleName: puzzleName,
-		State:      state,
-		CreatedAt:  time.Now(),
+		PuzzleName:     puzzleName,
+		State:          state,
+		CreatedAt:      now,
+		TimeLimit:      timeLimit,
+		Client:         client,
+		AttemptsLeft:   c.maxAttempts,
+		Chain:          chain,
+		ChainStartedAt: now,
 	}
 	c.sessionOrder = append(c.sessionOrder, sessionID)
 	c.sessionsMu.Unlock()
 
 	log.Printf("New challenge: session=%s, puzzle=%s", sessionID, puzzleName)
-	return sessionID, instructions
+	return sessionID, instructions, timeLimit
 }
 
-// validateAnswer checks if the answer is correct for the given session
-func (c *BotchaMiddleware) validateAnswer(sessionID, answer string) (bool, string) {
+// validateAnswer checks if the answer is correct for the given session.
+// A non-empty reason on success means the submission was accepted but flagged.
+// A failure with an empty reason means a chain round was solved and message
+// holds the next round's challenge.
+func (c *BotchaMiddleware) validateAnswer(sessionID, answer string, client ClientInfo, chain *Chain) (success bool, reason Reason, message string) {
 	c.sessionsMu.RLock()
 	session, exists := c.sessions[sessionID]
 	c.sessionsMu.RUnlock()
 
+	// Sessions can only be redeemed at the endpoint that issued them
+	if exists && session.Chain != chain {
+		log.Printf("Session %s was issued for a different endpoint", sessionID)
+		exists = false
+	}
+
 	if !exists {
 		log.Printf("Invalid session: %s", sessionID)
-		return false, `VERIFICATION FAILED - Invalid Session
+		return false, ReasonInvalidSession, invalidSessionMessage
+	}
 
-The session ID you provided does not exist or has already been used.
+	// A session can only be redeemed by the client it was issued to.
+	// The session is kept so the legitimate client can still answer.
+	if attr := c.binding.mismatch(session.Client, client); attr != "" {
+		log.Printf("Client mismatch for session %s: %s differs", sessionID, attr)
+		return false, ReasonClientMismatch, fmt.Sprintf(`VERIFICATION FAILED - Client Mismatch
 
-To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`
+This session was issued to a different client (the %s does not match).
+Answers must come from the same client that requested the challenge.
+
+To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`,
+			attr)
 	}
 
 	// Check timeout
 	elapsed := time.Since(session.CreatedAt)
-	if elapsed > sessionTimeout {
+	if elapsed > session.TimeLimit {
 		c.sessionsMu.Lock()
 		delete(c.sessions, sessionID)
 		c.sessionsMu.Unlock()
+		c.recordOutcome(session.PuzzleName, session.Client, outcomeExpired, elapsed)
 
 		log.Printf("Session expired: %s (took %.1fs)", sessionID, elapsed.Seconds())
-		return false, fmt.Sprintf(`VERIFICATION FAILED - Session Expired
+		return false, ReasonExpired, fmt.Sprintf(`VERIFICATION FAILED - Session Expired
 
 You took too long to respond. The session expired after %.1f seconds.
 The time limit is %d seconds.
 
 To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`,
-			elapsed.Seconds(), int(sessionTimeout.Seconds()))
+			elapsed.Seconds(), int(session.TimeLimit.Seconds()))
 	}
 
 	// Get the puzzle and validate
 	puzzle, ok := c.puzzles[session.PuzzleName]
 	if !ok {
 		log.Printf("Unknown puzzle type: %s", session.PuzzleName)
-		return false, "Internal error: unknown puzzle type"
+		return false, ReasonInternal, "Internal error: unknown puzzle type"
 	}
 
-	success := puzzle.Validate(session.State, answer)
+	// Answers faster than any genuine solver come from a script
+	minSolveTime := c.minSolveTimeFor(session.PuzzleName)
+	tooFast := elapsed < minSolveTime
+	if tooFast && c.tooFastAction == TooFastReject {
+		c.sessionsMu.Lock()
+		delete(c.sessions, sessionID)
+		c.sessionsMu.Unlock()
+		c.recordTooFast(session.PuzzleName)
 
-	// Clean up session regardless of result
-	c.sessionsMu.Lock()
-	delete(c.sessions, sessionID)
-	c.sessionsMu.Unlock()
+		log.Printf("Answer too fast for session %s (took %.2fs, minimum %.2fs)",
+			sessionID, elapsed.Seconds(), minSolveTime.Seconds())
+		return false, ReasonTooFast, fmt.Sprintf(`VERIFICATION FAILED - Answer Too Fast
+
+The answer arrived after %.2f seconds, faster than the puzzle can be solved by reasoning.
+
+To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`,
+			elapsed.Seconds())
+	}
+
+	// Claim the attempt before validating, so parallel submissions can neither
+	// exceed the attempt limit nor redeem one correct answer twice
+	attemptsLeft, claimed := c.claimAttempt(sessionID, session)
+	if !claimed {
+		log.Printf("Session %s was used up by another submission", sessionID)
+		return false, ReasonInvalidSession, invalidSessionMessage
+	}
+
+	success = puzzle.Validate(session.State, answer)
+
+	// The answer as submitted is tried first; normalizing only gets a second look
+	normalized := false
+	if !success && len(c.normalizers) > 0 {
+		if n := c.normalize(answer); n != answer && puzzle.Validate(session.State, n) {
+			success, normalized = true, true
+		}
+	}
 
 	if !success {
+		if attemptsLeft > 0 {
+			log.Printf("Wrong answer for session %s, %d attempts left", sessionID, attemptsLeft)
+			return false, ReasonIncorrect, fmt.Sprintf(`VERIFICATION FAILED - Incorrect Answer
+
+The answer you provided is not correct.
+
+Attempts remaining: %d
+Time remaining: %d seconds
+
+Submit another answer for the same puzzle: %s&answer=<word>`,
+				attemptsLeft, int((session.TimeLimit - elapsed).Seconds()), c.submitQuery(sessionID, session.Client))
+		}
+
+		// A concurrent correct answer may already have ended the round
+		if !c.endRound(sessionID, session, nil) {
+			return false, ReasonInvalidSession, invalidSessionMessage
+		}
+
+		// Too-fast submissions are kept out of the adaptive window, right or wrong
+		if tooFast {
+			c.recordTooFast(session.PuzzleName)
+		} else {
+			c.recordOutcome(session.PuzzleName, session.Client, outcomeWrong, elapsed)
+		}
 		log.Printf("Wrong answer for session %s", sessionID)
-		return false, `VERIFICATION FAILED - Incorrect Answer
+		return false, ReasonIncorrect, `VERIFICATION FAILED - Incorrect Answer
 
 The answer you provided is not correct.
 
 To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`
 	}
 
+	// Solved rounds cannot be reused; chains continue with the next round in
+	// the same session
+	var next *Session
+	chainExpired := false
+	if session.Chain != nil && session.Round+1 < len(session.Chain.Rounds) {
+		if nextRound, text, ok := c.advanceChain(sessionID, session); ok {
+			next, message = &nextRound, text
+		} else {
+			chainExpired = true
+		}
+	}
+	if !c.endRound(sessionID, session, next) {
+		log.Printf("Session %s was redeemed by another submission", sessionID)
+		return false, ReasonInvalidSession, invalidSessionMessage
+	}
+
+	if normalized {
+		c.recordNormalized(session.PuzzleName)
+		log.Printf("Session %s: answer %q was accepted after normalization", sessionID, answer)
+	}
+	if tooFast {
+		c.recordTooFast(session.PuzzleName)
+		log.Printf("Session %s flagged: answered in %.2fs, minimum %.2fs",
+			sessionID, elapsed.Seconds(), minSolveTime.Seconds())
+		reason = ReasonTooFast
+	} else {
+		c.recordOutcome(session.PuzzleName, session.Client, outcomeSolved, elapsed)
+	}
+
+	if chainExpired {
+		log.Printf("Chain session %s ran out of time after round %d of %d",
+			sessionID, session.Round+1, len(session.Chain.Rounds))
+		return false, ReasonExpired, fmt.Sprintf(`VERIFICATION FAILED - Chain Deadline Reached
+
+Round %d of %d was solved, but too little of the %d-second deadline is left for the next round.
+
+To try again, make a fresh request to the main URL without any parameters to receive a new challenge.`,
+			session.Round+1, len(session.Chain.Rounds), int(session.Chain.Deadline.Seconds()))
+	}
+	if next != nil {
+		log.Printf("Chain session %s advanced to round %d of %d: puzzle=%s",
+			sessionID, next.Round+1, len(next.Chain.Rounds), next.PuzzleName)
+		return false, "", message
+	}
+
 	log.Printf("Session %s verified successfully in %.2fs", sessionID, elapsed.Seconds())
-	return true, ""
+	return true, reason, ""
+}
+
+// claimAttempt uses up one attempt of the round read as session and returns
+// how many are left. It fails if no attempts are left or the round was
+// meanwhile redeemed by another submission.
+func (c *BotchaMiddleware) claimAttempt(sessionID string, session Session) (int, bool) {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+
+	current, exists := c.sessions[sessionID]
+	if !exists || !current.CreatedAt.Equal(session.CreatedAt) || current.AttemptsLeft <= 0 {
+		return 0, false
+	}
+	current.AttemptsLeft--
+	c.sessions[sessionID] = current
+	return current.AttemptsLeft, true
+}
+
+// endRound ends the round read as session, replacing the session with next or
+// deleting it. It fails if another submission ended the round first.
+func (c *BotchaMiddleware) endRound(sessionID string, session Session, next *Session) bool {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+
+	current, exists := c.sessions[sessionID]
+	if !exists || !current.CreatedAt.Equal(session.CreatedAt) {
+		return false
+	}
+	if next != nil {
+		c.sessions[sessionID] = *next
+	} else {
+		delete(c.sessions, sessionID)
+	}
+	return true
+}
+
+// submitQuery returns the query string a client uses to answer the given session
+func (c *BotchaMiddleware) submitQuery(sessionID string, client ClientInfo) string {
+	query := "?session=" + sessionID
+	if c.binding.Nonce && client.Nonce != "" {
+		query += "&nonce=<your nonce>"
+	}
+	return query
 }
 
 // Middleware returns an HTTP middleware that guards the next handler with a challenge
 func (c *BotchaMiddleware) Middleware(next http.Handler) http.Handler {
+	return c.guard(nil, next)
+}
+
+// guard serves challenges for a single puzzle or, with a chain, a sequence of puzzles
+func (c *BotchaMiddleware) guard(chain *Chain, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 
 		answer := strings.TrimSpace(r.URL.Query().Get("answer"))
 		sessionID := r.URL.Query().Get("session")
+		client := clientInfoFromRequest(r)
 
 		// If both answer and session are provided, verify the challenge
 		if answer != "" && sessionID != "" {
-			success, message := c.validateAnswer(sessionID, answer)
+			success, reason, message := c.validateAnswer(sessionID, answer, client, chain)
+			if reason != "" {
+				w.Header().Set("X-Botcha-Reason", string(reason))
+			}
 			if success {
 				// Verified - pass through to the actual handler
 				next.ServeHTTP(w, r)
 				return
 			}
-			// Failed verification
+			// Failed verification or the next round of a chain
 			fmt.Fprint(w, message)
 			return
 		}
 
 		// No valid attempt - generate new challenge
-		sessionID, puzzleInstructions := c.generateChallenge()
+		sessionID, puzzleInstructions, timeLimit := c.generateChallenge(client, chain)
 
 		fmt.Fprintf(w, `Prove that you are an AI agent to access the protected content.
 
-%s
+%s%s
 
 NOTE: The puzzle varies with every request.
 Solve it through direct reasoning. Do not write scripts or code.
 
-Submit answer within %d seconds: ?session=%s&answer=<word>
-`, puzzleInstructions, int(sessionTimeout.Seconds()), sessionID)
+Submit answer within %d seconds: %s&answer=<word>
+`, chainIntro(chain), puzzleInstructions, int(timeLimit.Seconds()), c.submitQuery(sessionID, client))
 	})
 }
@@ -0,0 +1,115 @@
+package challenge
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// testPuzzle asks for a fixed answer
+type testPuzzle struct {
+	name, answer string
+}
+
+func (p testPuzzle) Name() string { return p.name }
+
+func (p testPuzzle) Generate() (string, any) { return "Answer with " + p.answer + ".", p.answer }
+
+func (p testPuzzle) Validate(state any, answer string) bool { return answer == state }
+
+func (p testPuzzle) Answer(state any) string { return state.(string) }
+
+// GenerateWithKey asks for the key followed by the puzzle's own answer
+func (p testPuzzle) GenerateWithKey(key string) (string, any) {
+	return "Answer with the key followed by " + p.answer + ".", key + p.answer
+}
+
+// protected is the handler guarded in the tests
+var protected = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, "protected content")
+})
+
+func newTestMiddleware(puzzles ...Puzzle) *BotchaMiddleware {
+	c := New()
+	for _, p := range puzzles {
+		c.RegisterPuzzle(p)
+	}
+	return c
+}
+
+// testClient sends requests from one address and user agent
+type testClient struct {
+	addr, userAgent string
+}
+
+var defaultClient = testClient{addr: "192.0.2.10:4000", userAgent: "agent/1.0"}
+
+// get requests the handler with the given query and returns the body and the
+// X-Botcha-Reason header
+func (tc testClient) get(h http.Handler, query url.Values) (body string, reason Reason) {
+	req := httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	req.RemoteAddr = tc.addr
+	req.Header.Set("User-Agent", tc.userAgent)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Body.String(), Reason(rec.Header().Get("X-Botcha-Reason"))
+}
+
+var sessionParam = regexp.MustCompile(`\?session=(\w+)`)
+
+// challenge fetches a new challenge and returns its session ID
+func (tc testClient) challenge(t *testing.T, h http.Handler) string {
+	t.Helper()
+	body, _ := tc.get(h, url.Values{})
+	m := sessionParam.FindStringSubmatch(body)
+	if m == nil {
+		t.Fatalf("no session in challenge:\n%s", body)
+	}
+	return m[1]
+}
+
+// answer submits an answer for a session
+func (tc testClient) answer(h http.Handler, sessionID, answer string) (string, Reason) {
+	return tc.get(h, url.Values{"session": {sessionID}, "answer": {answer}})
+}
+
+func TestCorrectAnswerIsRedeemedOnce(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	if body, reason := defaultClient.answer(h, id, "apple"); body != "protected content" || reason != "" {
+		t.Fatalf("correct answer: got %q, reason %q", body, reason)
+	}
+	if _, reason := defaultClient.answer(h, id, "apple"); reason != ReasonInvalidSession {
+		t.Errorf("second redemption: got reason %q, want %q", reason, ReasonInvalidSession)
+	}
+}
+
+func TestExpiredSession(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	session := c.sessions[id]
+	session.CreatedAt = session.CreatedAt.Add(-time.Hour)
+	c.sessions[id] = session
+
+	if _, reason := defaultClient.answer(h, id, "apple"); reason != ReasonExpired {
+		t.Errorf("got reason %q, want %q", reason, ReasonExpired)
+	}
+	if c.stats["test"].total != 1 {
+		t.Errorf("expired session not recorded")
+	}
+}
@@ -0,0 +1,88 @@
+package challenge
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer rewrites an answer into a more canonical form.
+// Normalizers run in order and each receives the output of the previous one.
+type Normalizer func(answer string) string
+
+// DefaultNormalizers returns the normalizer chain installed by New
+func DefaultNormalizers() []Normalizer {
+	return []Normalizer{NormalizeNFKC, StripPunctuation, ExtractAnswer, FoldDiacritics}
+}
+
+// SetNormalizers replaces the answer normalizer chain shared by all puzzles.
+// Answers are validated as submitted first and normalized only if that fails.
+// Calling it without arguments disables normalization.
+// Must be called before the middleware starts serving requests.
+func (c *BotchaMiddleware) SetNormalizers(steps ...Normalizer) {
+	c.normalizers = steps
+	log.Printf("Answer normalizers: %d", len(steps))
+}
+
+// normalize runs an answer through the normalizer chain
+func (c *BotchaMiddleware) normalize(answer string) string {
+	for _, step := range c.normalizers {
+		answer = step(answer)
+	}
+	return answer
+}
+
+// NormalizeNFKC folds Unicode compatibility characters, such as full-width
+// letters and ligatures, into their plain equivalents
+func NormalizeNFKC(answer string) string {
+	return norm.NFKC.String(answer)
+}
+
+// surroundingQuotes are stripped from both ends of an answer
+const surroundingQuotes = "\"'`“”‘’«»„*_"
+
+// trailingPunctuation is stripped from the end of an answer
+const trailingPunctuation = ".!?,;:"
+
+// StripPunctuation removes quotes and emphasis around the answer and trailing
+// sentence punctuation. Punctuation inside the answer is kept.
+func StripPunctuation(answer string) string {
+	for {
+		trimmed := strings.TrimSpace(answer)
+		trimmed = strings.Trim(trimmed, surroundingQuotes)
+		trimmed = strings.TrimRight(trimmed, trailingPunctuation)
+		if trimmed == answer {
+			return trimmed
+		}
+		answer = trimmed
+	}
+}
+
+// answerLeadIn matches phrases agents put before the actual answer
+var answerLeadIn = regexp.MustCompile(`(?i)^(?:(?:(?:the|my|final)\s+)*(?:answer|word|solution|result)\s*(?:is\b\s*:?|:|=|-)|it\s+is\b|it's\b|it’s\b)\s*`)
+
+// ExtractAnswer removes a lead-in such as "The answer is" or "Final answer:"
+// and strips the quotes around what follows
+func ExtractAnswer(answer string) string {
+	loc := answerLeadIn.FindStringIndex(answer)
+	if loc == nil || loc[1] == len(answer) {
+		return answer
+	}
+	return StripPunctuation(answer[loc[1]:])
+}
+
+// FoldDiacritics removes accents and other combining marks ("café" becomes "cafe")
+func FoldDiacritics(answer string) string {
+	// Transformers keep state, so each call builds its own chain
+	folder := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(folder, answer)
+	if err != nil {
+		return answer
+	}
+	return folded
+}
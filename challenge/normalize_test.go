@@ -0,0 +1,45 @@
+package challenge
+
+import "testing"
+
+func TestDefaultNormalizers(t *testing.T) {
+	c := New()
+	tests := []struct {
+		answer, want string
+	}{
+		{"apple", "apple"},
+		{`"apple".`, "apple"},
+		{"**apple**", "apple"},
+		{"The answer is: apple!", "apple"},
+		{"Final answer = 'apple'", "apple"},
+		{"It's apple.", "apple"},
+		{"ａｐｐｌｅ", "apple"},
+		{"café", "cafe"},
+		{"rock-and-roll.", "rock-and-roll"},
+		{"The answer is", "The answer is"},
+	}
+	for _, tt := range tests {
+		if got := c.normalize(tt.answer); got != tt.want {
+			t.Errorf("normalize(%q) = %q, want %q", tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizedAnswers(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	if body, _ := defaultClient.answer(h, id, `The answer is "apple".`); body != "protected content" {
+		t.Fatalf("normalized answer: got %q", body)
+	}
+	if c.stats["test"].normalized != 1 {
+		t.Errorf("normalized solve not counted")
+	}
+
+	c.SetNormalizers()
+	id = defaultClient.challenge(t, h)
+	if _, reason := defaultClient.answer(h, id, `The answer is "apple".`); reason != ReasonIncorrect {
+		t.Errorf("without normalizers: got reason %q, want %q", reason, ReasonIncorrect)
+	}
+}
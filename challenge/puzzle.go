@@ -13,3 +13,29 @@ type Puzzle interface {
 	// Validate checks if the provided answer is correct for the given state
 	Validate(state any, answer string) bool
 }
+
+// Difficulty holds the knobs that can be turned to make a puzzle harder or easier
+type Difficulty struct {
+	HiddenPositions int `json:"hidden_positions"`
+	MinWordLength   int `json:"min_word_length"`
+	ExtraLetters    int `json:"extra_letters"`
+}
+
+// Tunable is implemented by puzzles whose difficulty can be changed at runtime.
+// Implementations must be safe for concurrent use with Generate.
+type Tunable interface {
+	Difficulty() Difficulty
+	SetDifficulty(d Difficulty)
+}
+
+// Answerer is implemented by puzzles that can report the expected answer of a
+// generated state, which lets composite puzzles feed it into another puzzle
+type Answerer interface {
+	Answer(state any) string
+}
+
+// KeyedGenerator is implemented by puzzles that can build a challenge around a
+// key taken from another puzzle's answer. The key is not shown to the solver.
+type KeyedGenerator interface {
+	GenerateWithKey(key string) (instructions string, state any)
+}
@@ -0,0 +1,56 @@
+package challenge
+
+import (
+	"log"
+	"time"
+)
+
+// TooFastAction decides what happens to answers submitted faster than the minimum solve time
+type TooFastAction int
+
+const (
+	// TooFastReject fails the submission with ReasonTooFast
+	TooFastReject TooFastAction = iota
+	// TooFastFlag accepts a correct submission but marks it with ReasonTooFast
+	TooFastFlag
+)
+
+// LatencyProfile describes how long a genuine solver is expected to take on a puzzle
+type LatencyProfile struct {
+	// MinSolveTime is the fastest plausible solve; quicker answers are too fast
+	MinSolveTime time.Duration
+	// Expected is the typical LLM solve time, used as the adaptive target latency
+	Expected time.Duration
+}
+
+// SetMinSolveTime sets the default lower bound on solve time for all puzzles
+// and what to do with answers that arrive sooner.
+// Must be called before the middleware starts serving requests.
+func (c *BotchaMiddleware) SetMinSolveTime(d time.Duration, action TooFastAction) {
+	c.minSolveTime = d
+	c.tooFastAction = action
+	log.Printf("Minimum solve time: %s", d)
+}
+
+// SetLatencyProfile overrides the minimum solve time and target latency of one puzzle.
+// Must be called before the middleware starts serving requests.
+func (c *BotchaMiddleware) SetLatencyProfile(puzzleName string, profile LatencyProfile) {
+	c.latencyProfiles[puzzleName] = profile
+	log.Printf("Latency profile for %s: min %s, expected %s", puzzleName, profile.MinSolveTime, profile.Expected)
+}
+
+// minSolveTimeFor returns the lower bound on solve time for the named puzzle
+func (c *BotchaMiddleware) minSolveTimeFor(puzzleName string) time.Duration {
+	if profile, ok := c.latencyProfiles[puzzleName]; ok {
+		return profile.MinSolveTime
+	}
+	return c.minSolveTime
+}
+
+// targetLatencyFor returns the latency the adaptive controller aims for on the named puzzle
+func (c *BotchaMiddleware) targetLatencyFor(puzzleName string) time.Duration {
+	if profile, ok := c.latencyProfiles[puzzleName]; ok && profile.Expected > 0 {
+		return profile.Expected
+	}
+	return c.adaptive.TargetLatency
+}
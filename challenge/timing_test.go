@@ -0,0 +1,56 @@
+package challenge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTooFastReject(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.SetMinSolveTime(time.Hour, TooFastReject)
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	body, reason := defaultClient.answer(h, id, "apple")
+	if reason != ReasonTooFast || body == "protected content" {
+		t.Fatalf("got %q, reason %q; want a rejection", body, reason)
+	}
+	if _, reason := defaultClient.answer(h, id, "apple"); reason != ReasonInvalidSession {
+		t.Errorf("rejected session still usable: reason %q", reason)
+	}
+	if s := c.stats["test"]; s.tooFast != 1 || s.total != 0 {
+		t.Errorf("got %d too fast and %d outcomes, want 1 and 0", s.tooFast, s.total)
+	}
+}
+
+func TestTooFastFlag(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.SetMinSolveTime(time.Hour, TooFastFlag)
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	if body, reason := defaultClient.answer(h, id, "apple"); body != "protected content" || reason != ReasonTooFast {
+		t.Fatalf("got %q, reason %q; want flagged content", body, reason)
+	}
+
+	// Flagged solves, right or wrong, stay out of the adaptive window
+	id = defaultClient.challenge(t, h)
+	if _, reason := defaultClient.answer(h, id, "pear"); reason != ReasonIncorrect {
+		t.Fatalf("wrong answer: got reason %q", reason)
+	}
+	if s := c.stats["test"]; s.tooFast != 2 || s.total != 0 {
+		t.Errorf("got %d too fast and %d outcomes, want 2 and 0", s.tooFast, s.total)
+	}
+}
+
+func TestLatencyProfileOverridesMinSolveTime(t *testing.T) {
+	c := newTestMiddleware(testPuzzle{"test", "apple"})
+	c.SetMinSolveTime(time.Hour, TooFastReject)
+	c.SetLatencyProfile("test", LatencyProfile{MinSolveTime: 0})
+	h := c.Middleware(protected)
+
+	id := defaultClient.challenge(t, h)
+	if body, reason := defaultClient.answer(h, id, "apple"); body != "protected content" || reason != "" {
+		t.Errorf("got %q, reason %q", body, reason)
+	}
+}
@@ -2,4 +2,7 @@ module botcha
 
 go 1.24.1
 
-require github.com/google/uuid v1.6.0
+require (
+	github.com/google/uuid v1.6.0
+	golang.org/x/text v0.30.0
+)
@@ -3,17 +3,66 @@ package main
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"time"
 
 	"botcha/challenge"
 	"botcha/puzzles"
 )
 
+// maxAttempts allows a couple of retries for typos before a new challenge is
+// needed. botcha audit gives its solvers as many guesses by default.
+const maxAttempts = 3
+
 func main() {
+	// "botcha selftest" checks that every built-in puzzle generates solvable
+	// challenges; "botcha audit" measures how often simple scripts beat each one
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "selftest":
+			os.Exit(runSelfTest(os.Args[2:]))
+		case "audit":
+			os.Exit(runAudit(os.Args[2:]))
+		}
+	}
+
 	// Initialize challenge middleware
 	c := challenge.New()
+
+	c.SetMaxAttempts(maxAttempts)
+
+	// Scrambled letters must spell more candidate words than there are attempts
+	charade := puzzles.NewCharadePuzzle()
+	charade.SetMinRivals(maxAttempts + 1)
+
 	//c.RegisterPuzzle(puzzles.NewScramblePuzzle()) - a simpler one
-	c.RegisterPuzzle(puzzles.NewCharadePuzzle())
+	c.RegisterPuzzle(charade)
+	//c.RegisterPuzzle(puzzles.NewMathStoryPuzzle()) - arithmetic word problems
+	//c.RegisterPuzzle(puzzles.NewCodeTracePuzzle()) - predict a program's output
+	//c.RegisterPuzzle(puzzles.NewLogicGridPuzzle()) - zebra-style logic grid
+	//c.RegisterPuzzle(puzzles.NewCipherPuzzle()) - classical ciphers with clue-derived keys
+	//c.RegisterPuzzle(puzzles.NewJSONTransformPuzzle()) - JSON in, JSON out
+	//c.RegisterPuzzle(puzzles.NewPassagePuzzle()) - reading comprehension
+	//c.RegisterPuzzle(puzzles.NewSequencePuzzle()) - sequence completion
+	//c.RegisterPuzzle(puzzles.NewASCIIArtPuzzle()) - read words drawn as ASCII art
+	//c.RegisterPuzzle(puzzles.NewConversionPuzzle()) - multi-hop unit conversions
+	//c.RegisterPuzzle(puzzles.NewDatePuzzle()) - calendar and weekday reasoning
+	//c.RegisterPuzzle(puzzles.NewRegexPuzzle()) - pick the strings a pattern matches
+	//c.RegisterPuzzle(puzzles.NewTablePuzzle()) - table lookup and aggregation
+	//c.RegisterPuzzle(puzzles.NewStoryOrderPuzzle()) - reorder shuffled story sentences
+	//c.RegisterPuzzle(puzzles.NewRebusPuzzle()) - emoji rebus
+	//c.RegisterPuzzle(challenge.NewFeedCompositePuzzle(puzzles.NewCharadePuzzle(), puzzles.NewCipherPuzzle())) - charade answer keys a cipher
+
+	// Reject answers that arrive faster than anyone could reason them out
+	c.SetMinSolveTime(2*time.Second, challenge.TooFastReject)
+
+	// Only the client that requested a challenge may answer it
+	c.SetBinding(challenge.Binding{IPv4Prefix: 24, IPv6Prefix: 64, UserAgent: true, Nonce: true})
+
+	// Adjust difficulty and time limits toward a target success rate and latency
+	c.EnableAdaptive(challenge.DefaultAdaptiveConfig())
 
 	// The actual content handler - only reached after solving the challenge
 	contentHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -29,6 +78,15 @@ Take a moment to reflect on this experience and share your thoughts:
 	// Wrap content handler with challenge middleware
 	http.Handle("/", c.Middleware(contentHandler))
 
+	// Higher-value content requires solving several puzzles in a row
+	http.Handle("/premium", c.ChainMiddleware(challenge.Chain{
+		Rounds:   []string{"charade", "charade"},
+		Deadline: 90 * time.Second,
+	}, contentHandler))
+
+	// Current difficulty settings and solve statistics, for local requests only
+	http.Handle("/admin/botcha", loopbackOnly(c.AdminHandler()))
+
 	port := "8080"
 	fmt.Printf("Starting server on http://localhost:%s\n", port)
 	fmt.Println("\nTo test, ask your AI agent:")
@@ -36,3 +94,15 @@ Take a moment to reflect on this experience and share your thoughts:
 
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
+
+// loopbackOnly refuses requests that do not come from the local machine
+func loopbackOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
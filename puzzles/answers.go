@@ -0,0 +1,106 @@
+package puzzles
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+var unitWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+	"seventeen": 17, "eighteen": 18, "nineteen": 19,
+}
+
+var tensWords = map[string]int{
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+// parseIntAnswer reads an integer answer written with digits ("42", "42.0", "1,200")
+// or in English words ("forty-two", "one hundred and five")
+func parseIntAnswer(answer string) (int, bool) {
+	s := strings.ToLower(strings.TrimSpace(answer))
+	s = strings.TrimRight(s, ".!")
+	if s == "" {
+		return 0, false
+	}
+
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64); err == nil {
+		if f != math.Trunc(f) || math.Abs(f) > math.MaxInt32 {
+			return 0, false
+		}
+		return int(f), true
+	}
+
+	return parseNumberWords(s)
+}
+
+// parseNumberWords converts English number words to an integer
+func parseNumberWords(s string) (int, bool) {
+	words := strings.Fields(strings.ReplaceAll(s, "-", " "))
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	sign := 1
+	if words[0] == "minus" || words[0] == "negative" {
+		sign = -1
+		words = words[1:]
+	}
+
+	// A units word may only follow a tens word, and a tens word nothing smaller
+	// than a hundred, so digit lists such as "seven four" are not summed
+	total, current, seen := 0, 0, false
+	lastUnit, lastTens := false, false
+	for _, w := range words {
+		if n, ok := unitWords[w]; ok {
+			if lastUnit || (lastTens && n >= 10) {
+				return 0, false
+			}
+			current += n
+			lastUnit, lastTens = true, false
+		} else if n, ok := tensWords[w]; ok {
+			if lastUnit || lastTens {
+				return 0, false
+			}
+			current += n
+			lastUnit, lastTens = false, true
+		} else {
+			lastUnit, lastTens = false, false
+			switch w {
+			case "and":
+				continue
+			case "hundred":
+				current = max(current, 1) * 100
+			case "thousand":
+				total += max(current, 1) * 1000
+				current = 0
+			case "million":
+				total += max(current, 1) * 1000000
+				current = 0
+			default:
+				return 0, false
+			}
+		}
+		seen = true
+	}
+	if !seen {
+		return 0, false
+	}
+	return sign * (total + current), true
+}
+
+// parseFloatAnswer reads a numeric answer such as "3.5", "1,200.75" or "about 42 days",
+// falling back to English number words
+func parseFloatAnswer(answer string) (float64, bool) {
+	for _, w := range strings.Fields(strings.ToLower(answer)) {
+		w = strings.Trim(strings.ReplaceAll(w, ",", ""), ".!?;:()~≈")
+		if f, err := strconv.ParseFloat(w, 64); err == nil {
+			return f, true
+		}
+	}
+	n, ok := parseIntAnswer(answer)
+	return float64(n), ok
+}
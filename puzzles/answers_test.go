@@ -0,0 +1,34 @@
+package puzzles
+
+import "testing"
+
+func TestParseIntAnswer(t *testing.T) {
+	tests := []struct {
+		answer string
+		want   int
+		ok     bool
+	}{
+		{"42", 42, true},
+		{"1,200", 1200, true},
+		{"42.0", 42, true},
+		{"forty-two", 42, true},
+		{"one hundred and five", 105, true},
+		{"two thousand three hundred", 2300, true},
+		{"minus seven", -7, true},
+		{"Twenty one.", 21, true},
+		{"seven four", 0, false},
+		{"one two", 0, false},
+		{"twenty thirty", 0, false},
+		{"five twenty", 0, false},
+		{"twenty eleven", 0, false},
+		{"3.5", 0, false},
+		{"and", 0, false},
+		{"lots", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseIntAnswer(tt.answer)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseIntAnswer(%q) = %d, %t; want %d, %t", tt.answer, got, ok, tt.want, tt.ok)
+		}
+	}
+}
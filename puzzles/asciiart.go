@@ -0,0 +1,221 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ASCIIArtState holds the puzzle state stored in the session
+type ASCIIArtState struct {
+	Text string
+}
+
+// ASCIIArtPuzzle implements the ASCII-art reading challenge
+type ASCIIArtPuzzle struct{}
+
+// NewASCIIArtPuzzle creates a new ASCII-art puzzle instance
+func NewASCIIArtPuzzle() *ASCIIArtPuzzle {
+	return &ASCIIArtPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *ASCIIArtPuzzle) Name() string {
+	return "asciiart"
+}
+
+// Layout of the rendered art. Each glyph gets a slot wide enough for a
+// shift to the right, a lean of up to two columns and a gap.
+const (
+	glyphHeight    = 5
+	glyphWidth     = 5
+	glyphSlot      = glyphWidth + 4
+	glyphsPerBand  = 7
+	artNoiseChance = 0.08
+)
+
+// codeAlphabet leaves out characters that look alike (0/O, 1/I)
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Generate creates a new ASCII-art challenge
+func (p *ASCIIArtPuzzle) Generate() (instructions string, state any) {
+	var text string
+	if rand.Intn(2) == 0 {
+		text = strings.ToUpper(ChallengeWords[rand.Intn(len(ChallengeWords))])
+	} else {
+		code := make([]byte, between(5, 6))
+		for i := range code {
+			code[i] = codeAlphabet[rand.Intn(len(codeAlphabet))]
+		}
+		text = string(code)
+	}
+
+	art := renderArt(text)
+
+	instructions = fmt.Sprintf(`Read the text drawn below. It is split into bands; read them left to right, top to bottom.
+
+Letters are five rows high, and some lean to the right.
+Letters drawn mirrored, as seen in a looking glass, are decoys: ignore them.
+Stray dots and commas are noise.
+
+%s
+Answer with the text you read, without spaces.`, art)
+
+	return instructions, ASCIIArtState{Text: text}
+}
+
+// Validate checks if the answer matches the rendered text, ignoring case and spaces
+func (p *ASCIIArtPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(ASCIIArtState)
+	if !ok {
+		return false
+	}
+	answer = strings.Join(strings.Fields(strings.ReplaceAll(answer, "-", " ")), "")
+	return strings.EqualFold(answer, s.Text)
+}
+
+// artGlyph is a glyph to draw; decoys are mirrored glyphs
+type artGlyph struct {
+	shape [glyphHeight]string
+}
+
+// renderArt draws the text with decoy glyphs interleaved, jitter and noise
+func renderArt(text string) string {
+	var glyphs []artGlyph
+	for _, r := range text {
+		glyphs = append(glyphs, artGlyph{shape: artFont[r]})
+	}
+	decoys := mirroredArtFont()
+	for range between(2, 3) {
+		decoy := artGlyph{shape: decoys[rand.Intn(len(decoys))]}
+		pos := rand.Intn(len(glyphs) + 1)
+		glyphs = append(glyphs[:pos], append([]artGlyph{decoy}, glyphs[pos:]...)...)
+	}
+
+	var b strings.Builder
+	for start := 0; start < len(glyphs); start += glyphsPerBand {
+		band := glyphs[start:min(start+glyphsPerBand, len(glyphs))]
+		b.WriteString(renderBand(band))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderBand draws a row of glyphs with the same fill. Each glyph is shifted
+// down and to the right at random, and may lean so that its top rows move
+// right by up to two columns.
+func renderBand(band []artGlyph) string {
+	rows := make([][]byte, glyphHeight+1)
+	for i := range rows {
+		rows[i] = []byte(strings.Repeat(" ", len(band)*glyphSlot))
+	}
+
+	for gi, g := range band {
+		down, right, lean := rand.Intn(2), rand.Intn(2), rand.Intn(2)
+		for y, line := range g.shape {
+			left := gi*glyphSlot + right + glyphLean(y, lean)
+			for x := 0; x < len(line); x++ {
+				if line[x] == ' ' {
+					continue
+				}
+				rows[y+down][left+x] = "#@"[rand.Intn(2)]
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		for i, c := range row {
+			if c == ' ' && rand.Float64() < artNoiseChance {
+				row[i] = ".,"[rand.Intn(2)]
+			}
+		}
+		b.WriteString(strings.TrimRight(string(row), " "))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// glyphLean returns how far row y of a glyph moves right when it leans
+func glyphLean(y, lean int) int {
+	return lean * (glyphHeight - 1 - y) / 2
+}
+
+// artFont is a 5x5 font for A-Z and the digits used in codes
+var artFont = map[rune][glyphHeight]string{
+	'A': {" ### ", "#   #", "#####", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#### ", "#   #", "#### "},
+	'C': {" ####", "#    ", "#    ", "#    ", " ####"},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#### ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#### ", "#    ", "#    "},
+	'G': {" ####", "#    ", "#  ##", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#####", "#   #", "#   #"},
+	'I': {"#####", "  #  ", "  #  ", "  #  ", "#####"},
+	'J': {"#####", "   # ", "   # ", "#  # ", " ##  "},
+	'K': {"#   #", "#  # ", "###  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "#   #", "#   #"},
+	'N': {"#   #", "##  #", "# # #", "#  ##", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#### ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#### ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", " ### ", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "# # #", "## ##", "#   #"},
+	'X': {"#   #", " # # ", "  #  ", " # # ", "#   #"},
+	'Y': {"#   #", " # # ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "   # ", "  #  ", " #   ", "#####"},
+	'2': {" ### ", "#   #", "  ## ", " #   ", "#####"},
+	'3': {"#### ", "    #", " ### ", "    #", "#### "},
+	'4': {"#   #", "#   #", "#####", "    #", "    #"},
+	'5': {"#####", "#    ", "#### ", "    #", "#### "},
+	'6': {" ### ", "#    ", "#### ", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", "  #  "},
+	'8': {" ### ", "#   #", " ### ", "#   #", " ### "},
+	'9': {" ### ", "#   #", " ####", "    #", " ### "},
+}
+
+// mirroredArtFont holds the decoys: the glyphs of artFont mirrored left to
+// right, without those that look the same mirrored or differ from another
+// glyph in three cells or fewer
+var mirroredArtFont = sync.OnceValue(func() [][glyphHeight]string {
+	var decoys [][glyphHeight]string
+	for _, r := range codeAlphabet {
+		var mirrored [glyphHeight]string
+		for y, line := range artFont[r] {
+			b := []byte(line)
+			slices.Reverse(b)
+			mirrored[y] = string(b)
+		}
+		distinct := true
+		for _, shape := range artFont {
+			if glyphDistance(mirrored, shape) <= 3 {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			decoys = append(decoys, mirrored)
+		}
+	}
+	return decoys
+})
+
+// glyphDistance counts the cells in which two glyphs differ
+func glyphDistance(a, b [glyphHeight]string) int {
+	d := 0
+	for y := range a {
+		for x := range a[y] {
+			if a[y][x] != b[y][x] {
+				d++
+			}
+		}
+	}
+	return d
+}
@@ -0,0 +1,88 @@
+package puzzles
+
+import (
+	"slices"
+	"strings"
+)
+
+// solveASCIIArt is the reference solver: it cuts each band into glyph slots, skips the
+// decoys and matches the rest against the font
+func solveASCIIArt(instructions string) (string, bool) {
+	lines := strings.Split(instructions, "\n")
+	start := 0
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Stray dots") {
+			start = i + 2
+		}
+	}
+	if start == 0 {
+		return "", false
+	}
+
+	// Each band is glyphHeight+1 rows followed by a blank line
+	var text strings.Builder
+	for top := start; top+glyphHeight+1 < len(lines); top += glyphHeight + 2 {
+		band := lines[top : top+glyphHeight+1]
+		for slot := 0; ; slot++ {
+			r, decoy, ok := readGlyph(band, slot*glyphSlot)
+			if !ok {
+				break
+			}
+			if !decoy {
+				text.WriteRune(r)
+			}
+		}
+	}
+	return text.String(), text.Len() > 0
+}
+
+// readGlyph reads the glyph whose slot starts at column left, trying every
+// shift and lean that takes in all of the slot's ink. Mirrored glyphs are
+// decoys. It fails when the slot is empty or matches no glyph.
+func readGlyph(band []string, left int) (r rune, decoy bool, ok bool) {
+	inked := func(y, x int) bool {
+		return x < len(band[y]) && (band[y][x] == '#' || band[y][x] == '@')
+	}
+	ink := 0
+	for y := range band {
+		for x := left; x < left+glyphSlot; x++ {
+			if inked(y, x) {
+				ink++
+			}
+		}
+	}
+	if ink == 0 {
+		return 0, false, false
+	}
+
+	for down := range 2 {
+		for right := range 2 {
+			for lean := range 2 {
+				var shape [glyphHeight]string
+				found := 0
+				for y := range shape {
+					row := []byte(strings.Repeat(" ", glyphWidth))
+					for x := range glyphWidth {
+						if inked(y+down, left+right+glyphLean(y, lean)+x) {
+							row[x] = '#'
+							found++
+						}
+					}
+					shape[y] = string(row)
+				}
+				if found != ink {
+					continue
+				}
+				for glyph, s := range artFont {
+					if s == shape {
+						return glyph, false, true
+					}
+				}
+				if slices.Contains(mirroredArtFont(), shape) {
+					return 0, true, true
+				}
+			}
+		}
+	}
+	return 0, false, false
+}
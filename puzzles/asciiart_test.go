@@ -0,0 +1,40 @@
+package puzzles
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecoysAreFullHeightMirrors(t *testing.T) {
+	if len(mirroredArtFont()) < 8 {
+		t.Fatalf("only %d decoys", len(mirroredArtFont()))
+	}
+	for _, d := range mirroredArtFont() {
+		if !strings.Contains(d[0], "#") || !strings.Contains(d[glyphHeight-1], "#") {
+			t.Errorf("decoy %q is not %d rows high", d, glyphHeight)
+		}
+		for r, shape := range artFont {
+			if glyphDistance(d, shape) <= 3 {
+				t.Errorf("decoy %q looks like %c", d, r)
+			}
+		}
+	}
+}
+
+func TestASCIIArtAnswerFormats(t *testing.T) {
+	p := NewASCIIArtPuzzle()
+	state := ASCIIArtState{Text: "K7MX2"}
+	for answer, want := range map[string]bool{
+		"K7MX2":     true,
+		"k7mx2":     true,
+		"K7 MX2":    true,
+		"K7-MX-2":   true,
+		"K7MX":      false,
+		"K7MX2Z":    false,
+		"K 7 M X 3": false,
+	} {
+		if got := p.Validate(state, answer); got != want {
+			t.Errorf("Validate(%q) = %t, want %t", answer, got, want)
+		}
+	}
+}
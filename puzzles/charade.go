@@ -2,11 +2,12 @@ package puzzles
 
 import (
 	"fmt"
+	"math/bits"
 	"math/rand"
 	"strings"
 )
 
-// NumberQuestions maps each number (1-20) to a list of questions whose answer is that number
+// NumberQuestions maps each number (1-25) to a list of questions whose answer is that number
 var NumberQuestions = map[int][]string{
 	1: {
 		"number of moons orbiting Earth",
@@ -77,7 +78,7 @@ var NumberQuestions = map[int][]string{
 		"number of faces on a cube",
 		"number of wives of Henry VIII",
 		"half a dozen",
-		"number of balls in a standard pool rack minus nine",
+		"number of pockets on a pool table",
 		"number of noble gases that are stable",
 	},
 	7: {
@@ -232,7 +233,7 @@ var NumberQuestions = map[int][]string{
 		"last digit of the year of the first Moon landing",
 		"the hole in golf slang for the clubhouse bar",
 		"number of years in the Metonic cycle",
-		"number of angels named in Protestant Bible plus sixteen",
+		"number of lines across a Go board",
 		"prime number between seventeen and twenty-three",
 		"Adele's breakthrough album number",
 	},
@@ -248,6 +249,46 @@ var NumberQuestions = map[int][]string{
 		"number of questions in the classic guessing game",
 		"number of shillings in a pound before decimalization",
 	},
+	21: {
+		"atomic number of scandium",
+		"total number of pips on a standard six-sided die",
+		"number of guns in a royal salute",
+		"best hand value in blackjack",
+		"number of shillings in a guinea",
+		"legal drinking age in the United States",
+		"points needed to win a table tennis game under the old rules",
+	},
+	22: {
+		"atomic number of titanium",
+		"number of players on the pitch at the start of a soccer match",
+		"number of yards in a cricket pitch",
+		"number in the title of Joseph Heller's novel Catch-__",
+		"number of letters in the Hebrew alphabet",
+		"number of major arcana cards in a tarot deck",
+	},
+	23: {
+		"atomic number of vanadium",
+		"number of chromosome pairs in a human cell",
+		"Michael Jordan's jersey number with the Chicago Bulls",
+		"tilt of Earth's axis in whole degrees",
+		"number of the psalm that begins The Lord is my shepherd",
+	},
+	24: {
+		"atomic number of chromium",
+		"number of hours in a day",
+		"number of carats in pure gold",
+		"number of frames per second in traditional cinema",
+		"two dozen",
+		"number of letters in the Greek alphabet",
+	},
+	25: {
+		"atomic number of manganese",
+		"number of cents in a US quarter",
+		"square of five",
+		"day of December on which Christmas falls",
+		"number of years of marriage celebrated at a silver anniversary",
+		"a quarter of a hundred",
+	},
 }
 
 // CharadeState holds the puzzle state stored in the session
@@ -256,11 +297,13 @@ type CharadeState struct {
 }
 
 // CharadePuzzle implements the charade-style word unscrambling challenge
-type CharadePuzzle struct{}
+type CharadePuzzle struct {
+	scrambleTuning
+}
 
 // NewCharadePuzzle creates a new charade puzzle instance
 func NewCharadePuzzle() *CharadePuzzle {
-	return &CharadePuzzle{}
+	return &CharadePuzzle{scrambleTuning: newScrambleTuning()}
 }
 
 // Name returns the puzzle identifier
@@ -270,18 +313,31 @@ func (p *CharadePuzzle) Name() string {
 
 // Generate creates a new charade challenge
 func (p *CharadePuzzle) Generate() (instructions string, state any) {
-	word := ChallengeWords[rand.Intn(len(ChallengeWords))]
-	scrambled, descrambleSeq := ScrambleWord(word)
+	d := p.Difficulty()
+	for {
+		word, scrambled, descrambleSeq := p.drawScramble(d.MinWordLength, d.ExtraLetters)
+		hidden, ok := hidePositions(word, scrambled, descrambleSeq, d.HiddenPositions)
+		if !ok {
+			continue
+		}
+		compound, ok := compoundPositions(word, hidden, p.rivals())
+		if !ok {
+			continue
+		}
 
-	instructions = fmt.Sprintf(`Unscramble this word by solving the clues:
+		instructions = fmt.Sprintf(`Unscramble this word by solving the clues:
 
 Scrambled: %s
 Sequence: [%s]
 
 Each clue's answer is a number indicating the position in the scrambled word.`,
-		scrambled, formatCharadeSequence(descrambleSeq))
+			scrambled, formatCharadeSequence(descrambleSeq, hidden, compound))
+		if mentions(instructions, word) {
+			continue
+		}
 
-	return instructions, CharadeState{Word: word}
+		return instructions, CharadeState{Word: word}
+	}
 }
 
 // Validate checks if the answer matches the expected word
@@ -290,7 +346,13 @@ func (p *CharadePuzzle) Validate(state any, answer string) bool {
 	if !ok {
 		return false
 	}
-	return strings.EqualFold(answer, s.Word)
+	return matchesWord(answer, s.Word)
+}
+
+// Answer returns the expected word, so the puzzle can key another puzzle
+func (p *CharadePuzzle) Answer(state any) string {
+	s, _ := state.(CharadeState)
+	return s.Word
 }
 
 func getQuestionForNumber(n int) string {
@@ -301,18 +363,90 @@ func getQuestionForNumber(n int) string {
 	return questions[rand.Intn(len(questions))]
 }
 
-func formatCharadeSequence(seq []int) string {
-	strs := make([]string, len(seq))
-	for i, n := range seq {
-		strs[i] = getQuestionForNumber(n)
+// compoundPositions picks the positions whose clues are given as a sum or
+// difference of two clues, enough that at least rivals other words of the same
+// length fit the letters of the plain clues. This only varies the clues: a
+// script splits them on "plus" and "minus" and looks up both halves. Each
+// round makes the rival that needs the fewest compound positions fit. It
+// reports false if the word does not have enough rivals.
+func compoundPositions(word string, hidden map[int]bool, rivals int) (map[int]bool, bool) {
+	var differences []uint32
+	for _, w := range wordsByLength[len(word)] {
+		if w != word {
+			differences = append(differences, differingPositions(word, w))
+		}
+	}
+
+	var unreadable uint32
+	for i := range hidden {
+		unreadable |= 1 << i
+	}
+	for {
+		fitting, fewest := 0, len(word)+1
+		var cheapest []uint32
+		for _, diff := range differences {
+			need := bits.OnesCount32(diff &^ unreadable)
+			switch {
+			case need == 0:
+				fitting++
+			case need < fewest:
+				fewest, cheapest = need, []uint32{diff}
+			case need == fewest:
+				cheapest = append(cheapest, diff)
+			}
+		}
+		if fitting >= rivals {
+			break
+		}
+		if len(cheapest) == 0 {
+			return nil, false
+		}
+		unreadable |= cheapest[rand.Intn(len(cheapest))]
+	}
+
+	compound := make(map[int]bool)
+	for i := range len(word) {
+		if unreadable&(1<<i) != 0 && !hidden[i] {
+			compound[i] = true
+		}
+	}
+	return compound, true
+}
+
+// differingPositions returns a bit mask of the positions where two words of
+// the same length differ
+func differingPositions(a, b string) uint32 {
+	var mask uint32
+	for i := range len(a) {
+		if a[i] != b[i] {
+			mask |= 1 << i
+		}
 	}
+	return mask
+}
 
-	// Hide random positions
-	hiddenCount := min(HiddenPositions, len(strs))
-	perm := rand.Perm(len(strs))
-	for i := range hiddenCount {
-		strs[perm[i]] = "??"
+// compoundClue describes n as the sum or difference of two numbers that have clues
+func compoundClue(n int) string {
+	largest := len(NumberQuestions)
+	if n >= 2 && (n >= largest || rand.Intn(2) == 0) {
+		a := between(1, n-1)
+		return getQuestionForNumber(a) + " plus " + getQuestionForNumber(n-a)
 	}
+	b := between(1, largest-n)
+	return getQuestionForNumber(n+b) + " minus " + getQuestionForNumber(b)
+}
 
+func formatCharadeSequence(seq []int, hidden, compound map[int]bool) string {
+	strs := make([]string, len(seq))
+	for i, n := range seq {
+		switch {
+		case hidden[i]:
+			strs[i] = "??"
+		case compound[i]:
+			strs[i] = compoundClue(n)
+		default:
+			strs[i] = getQuestionForNumber(n)
+		}
+	}
 	return strings.Join(strs, ", ")
 }
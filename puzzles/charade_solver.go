@@ -0,0 +1,54 @@
+package puzzles
+
+import "strings"
+
+// solveCharade is the reference solver: it maps each clue back to its number and
+// fills the hidden positions by trying the unused letters
+func solveCharade(instructions string) (string, bool) {
+	scrambled, ok := instructionLine(instructions, "Scrambled: ")
+	list, ok2 := instructionLine(instructions, "Sequence: ")
+	if !ok || !ok2 {
+		return "", false
+	}
+	var seq []int
+	for _, clue := range sequenceItems(list) {
+		if clue == "??" {
+			seq = append(seq, 0)
+			continue
+		}
+		n, ok := charadeClueNumber(clue)
+		if !ok {
+			return "", false
+		}
+		seq = append(seq, n)
+	}
+	return descramble(scrambled, seq)
+}
+
+// charadeClueNumber reads a clue, which may be the sum or difference of two clues
+func charadeClueNumber(clue string) (int, bool) {
+	if a, b, ok := strings.Cut(clue, " plus "); ok {
+		x, ok := numberForClue(a)
+		y, ok2 := numberForClue(b)
+		return x + y, ok && ok2
+	}
+	if a, b, ok := strings.Cut(clue, " minus "); ok {
+		x, ok := numberForClue(a)
+		y, ok2 := numberForClue(b)
+		return x - y, ok && ok2
+	}
+	return numberForClue(clue)
+}
+
+// matchesPattern reports whether word fits a pattern in which '?' matches any letter
+func matchesPattern(word string, pattern []byte) bool {
+	if len(word) != len(pattern) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != '?' && pattern[i] != word[i] {
+			return false
+		}
+	}
+	return true
+}
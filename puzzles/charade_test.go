@@ -0,0 +1,47 @@
+package puzzles
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumberQuestionsCanBeCombined(t *testing.T) {
+	for n, questions := range NumberQuestions {
+		for _, q := range questions {
+			if strings.Contains(q, " plus ") || strings.Contains(q, " minus ") || strings.Contains(q, ", ") {
+				t.Errorf("clue for %d cannot be part of a compound clue: %q", n, q)
+			}
+		}
+	}
+	for n := 1; n <= len(NumberQuestions); n++ {
+		if got, ok := charadeClueNumber(compoundClue(n)); !ok || got != n {
+			t.Errorf("compound clue for %d reads as %d", n, got)
+		}
+	}
+}
+
+func TestCompoundPositionsLeaveRivals(t *testing.T) {
+	for range 200 {
+		word := pickWord(0)
+		hidden := map[int]bool{0: true}
+		compound, ok := compoundPositions(word, hidden, MinRivals)
+		if !ok {
+			continue
+		}
+		pattern := []byte(word)
+		for i := range pattern {
+			if hidden[i] || compound[i] {
+				pattern[i] = '?'
+			}
+		}
+		fitting := 0
+		for _, w := range wordsByLength[len(word)] {
+			if w != word && matchesPattern(w, pattern) {
+				fitting++
+			}
+		}
+		if fitting < MinRivals {
+			t.Errorf("%s: only %d rivals fit %s", word, fitting, pattern)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// CipherState holds the puzzle state stored in the session. Word is either a
+// single word or a short sentence of lowercase words separated by spaces.
+type CipherState struct {
+	Word string
+}
+
+// CipherPuzzle implements the classical cipher decoding challenge
+type CipherPuzzle struct{}
+
+// NewCipherPuzzle creates a new cipher puzzle instance
+func NewCipherPuzzle() *CipherPuzzle {
+	return &CipherPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *CipherPuzzle) Name() string {
+	return "cipher"
+}
+
+// cipherSentences wrap a word from ChallengeWords into a short sentence; they
+// work with any part of speech
+var cipherSentences = []string{
+	"the password is %s",
+	"the secret word is %s",
+	"remember the word %s",
+	"my favourite word is %s",
+	"please write down %s",
+	"the last clue is %s",
+	"say %s out loud",
+	"nobody guessed %s",
+}
+
+// cipherSchemes each encode a word or sentence and describe how it was encoded.
+// Spaces between words are kept as they are.
+var cipherSchemes = []func(word string) (encoded, description string){
+	caesarScheme,
+	atbashScheme,
+	vigenereScheme,
+	letterNumberScheme,
+}
+
+// Generate creates a new cipher challenge
+func (p *CipherPuzzle) Generate() (instructions string, state any) {
+	for {
+		word := ChallengeWords[rand.Intn(len(ChallengeWords))]
+		plain, kind := word, "word"
+		if rand.Intn(3) == 0 {
+			plain = fmt.Sprintf(cipherSentences[rand.Intn(len(cipherSentences))], word)
+			kind = "sentence"
+		}
+		encoded, description := cipherSchemes[rand.Intn(len(cipherSchemes))](plain)
+
+		instructions = fmt.Sprintf(`Decode this %s:
+
+Encoded: %s
+
+%s`, kind, encoded, description)
+		if mentions(instructions, word) {
+			continue
+		}
+
+		return instructions, CipherState{Word: plain}
+	}
+}
+
+// Validate checks if the answer matches the expected word or sentence; runs of
+// spaces between words count as one
+func (p *CipherPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(CipherState)
+	if !ok {
+		return false
+	}
+	return matchesWord(strings.Join(strings.Fields(answer), " "), s.Word)
+}
+
+// Answer returns the expected word, so the puzzle can key another puzzle
+func (p *CipherPuzzle) Answer(state any) string {
+	s, _ := state.(CipherState)
+	return s.Word
+}
+
+// keyedCodeLength is the number of random letters encoded with a key taken
+// from another puzzle. They spell no word, so decoding with every corpus word
+// as the key does not reveal which key is right.
+const keyedCodeLength = 8
+
+// GenerateWithKey creates a Vigenère challenge whose key is a word the solver
+// must already know, such as the answer to another puzzle. The plaintext is a
+// random code rather than a challenge word.
+func (p *CipherPuzzle) GenerateWithKey(key string) (instructions string, state any) {
+	var shifts []int
+	for _, r := range strings.ToLower(key) {
+		if r >= 'a' && r <= 'z' {
+			shifts = append(shifts, int(r-'a'))
+		}
+	}
+	if len(shifts) == 0 {
+		return p.Generate()
+	}
+
+	code := make([]byte, keyedCodeLength)
+	for i := range code {
+		code[i] = byte('a' + rand.Intn(26))
+	}
+
+	instructions = fmt.Sprintf(`Decode this code of %d random letters:
+
+Encoded: %s
+
+This is a Vigenère cipher. The key is the answer to the previous part, in lowercase letters.
+Each letter of the code was shifted forward by the matching key letter (a shifts by 0, b by 1, and so on), repeating the key as needed.`,
+		keyedCodeLength, vigenere(string(code), shifts))
+
+	return instructions, CipherState{Word: string(code)}
+}
+
+// shiftLetter moves a lowercase letter forward by n places, wrapping around.
+// Other characters, such as spaces, are returned unchanged.
+func shiftLetter(r rune, n int) rune {
+	if r < 'a' || r > 'z' {
+		return r
+	}
+	return 'a' + rune(((int(r-'a')+n)%26+26)%26)
+}
+
+func caesarScheme(word string) (string, string) {
+	shift := between(1, 20)
+	var b strings.Builder
+	for _, r := range word {
+		b.WriteRune(shiftLetter(r, shift))
+	}
+	return b.String(), fmt.Sprintf(
+		"Each letter was shifted forward in the alphabet by N places, wrapping around from z to a. N is the answer to this clue: %s.",
+		getQuestionForNumber(shift))
+}
+
+func atbashScheme(word string) (string, string) {
+	var b strings.Builder
+	for _, r := range word {
+		if r >= 'a' && r <= 'z' {
+			r = 'z' - (r - 'a')
+		}
+		b.WriteRune(r)
+	}
+	return b.String(), "Each letter was replaced by its mirror in the alphabet: a and z swap places, b and y, c and x, and so on."
+}
+
+func vigenereScheme(word string) (string, string) {
+	keyLen := between(3, 4)
+	key := make([]int, keyLen)
+	clues := make([]string, keyLen)
+	for i := range key {
+		// Clues are drawn from 1-20, so key letters range from a to t
+		key[i] = between(1, 20)
+		clues[i] = getQuestionForNumber(key[i])
+	}
+
+	shifts := make([]int, keyLen)
+	for i, k := range key {
+		shifts[i] = k - 1
+	}
+	return vigenere(word, shifts), fmt.Sprintf(`This is a Vigenère cipher. The key is a %d-letter word; each clue below gives the alphabet position (a=1) of one key letter, in order:
+[%s]
+
+Each letter was shifted forward by the matching key letter (a shifts by 0, b by 1, and so on), repeating the key as needed. Spaces do not use up key letters.`,
+		keyLen, strings.Join(clues, ", "))
+}
+
+// vigenere shifts each letter by the matching shift, repeating the shifts as
+// needed. Spaces are kept and do not use up a shift.
+func vigenere(word string, shifts []int) string {
+	var b strings.Builder
+	i := 0
+	for _, r := range word {
+		if r == ' ' {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteRune(shiftLetter(r, shifts[i%len(shifts)]))
+		i++
+	}
+	return b.String()
+}
+
+func letterNumberScheme(word string) (string, string) {
+	offset := between(1, 20)
+	var encoded []string
+	for _, w := range strings.Fields(word) {
+		numbers := make([]string, 0, len(w))
+		for _, r := range w {
+			numbers = append(numbers, strconv.Itoa(int(r-'a')+1+offset))
+		}
+		encoded = append(encoded, strings.Join(numbers, "-"))
+	}
+	separator := ""
+	if len(encoded) > 1 {
+		separator = ", and words are separated by slashes"
+	}
+	return strings.Join(encoded, " / "), fmt.Sprintf(
+		"Each letter was replaced by its alphabet position (a=1) plus N%s. N is the answer to this clue: %s.",
+		separator, getQuestionForNumber(offset))
+}
@@ -0,0 +1,75 @@
+package puzzles
+
+import (
+	"strconv"
+	"strings"
+)
+
+// solveCipher is the reference solver: it decodes the word using the scheme described
+// in the instructions. Keyed challenges depend on another puzzle's answer and
+// cannot be solved on their own.
+func solveCipher(instructions string) (string, bool) {
+	encoded, ok := instructionLine(instructions, "Encoded: ")
+	if !ok {
+		return "", false
+	}
+
+	var word strings.Builder
+	switch {
+	case strings.Contains(instructions, "mirror in the alphabet"):
+		for _, r := range encoded {
+			if r >= 'a' && r <= 'z' {
+				r = 'z' - (r - 'a')
+			}
+			word.WriteRune(r)
+		}
+	case strings.Contains(instructions, "Vigenère"):
+		list, ok := instructionLine(instructions, "[")
+		if !ok {
+			return "", false
+		}
+		var shifts []int
+		for _, clue := range sequenceItems("[" + list) {
+			n, ok := numberForClue(clue)
+			if !ok {
+				return "", false
+			}
+			shifts = append(shifts, -(n - 1))
+		}
+		return vigenere(encoded, shifts), true
+	case strings.Contains(instructions, "shifted forward in the alphabet by N places"):
+		shift, ok := numberForClue(cipherClue(instructions))
+		if !ok {
+			return "", false
+		}
+		for _, r := range encoded {
+			word.WriteRune(shiftLetter(r, -shift))
+		}
+	case strings.Contains(instructions, "alphabet position (a=1) plus N"):
+		offset, ok := numberForClue(cipherClue(instructions))
+		if !ok {
+			return "", false
+		}
+		for i, group := range strings.Split(encoded, " / ") {
+			if i > 0 {
+				word.WriteRune(' ')
+			}
+			for _, field := range strings.Split(group, "-") {
+				n, err := strconv.Atoi(field)
+				if err != nil {
+					return "", false
+				}
+				word.WriteRune(rune('a' + n - 1 - offset))
+			}
+		}
+	default:
+		return "", false
+	}
+	return word.String(), true
+}
+
+// cipherClue returns the clue that ends a single-number key description
+func cipherClue(instructions string) string {
+	_, clue, _ := strings.Cut(instructions, "answer to this clue: ")
+	return strings.TrimSuffix(strings.TrimSpace(clue), ".")
+}
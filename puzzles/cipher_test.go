@@ -0,0 +1,38 @@
+package puzzles
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKeyedCipherResistsCorpusKeys(t *testing.T) {
+	p := NewCipherPuzzle()
+	for range 20 {
+		key := ChallengeWords[rand.Intn(len(ChallengeWords))]
+		instructions, state := p.GenerateWithKey(key)
+		encoded, ok := instructionLine(instructions, "Encoded: ")
+		if !ok {
+			t.Fatalf("no encoded text:\n%s", instructions)
+		}
+
+		// Decoding with the right key gives the answer
+		var shifts []int
+		for _, r := range key {
+			shifts = append(shifts, -int(r-'a'))
+		}
+		if !p.Validate(state, vigenere(encoded, shifts)) {
+			t.Fatalf("decoding with %q rejected:\n%s", key, instructions)
+		}
+
+		// No corpus word, the right key included, decodes it to another word
+		for _, k := range ChallengeWords {
+			shifts = shifts[:0]
+			for _, r := range k {
+				shifts = append(shifts, -int(r-'a'))
+			}
+			if w := vigenere(encoded, shifts); challengeWordSet()[w] {
+				t.Errorf("key %q decodes %s to the word %q", k, encoded, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// CodeTraceState holds the puzzle state stored in the session
+type CodeTraceState struct {
+	Output string
+}
+
+// CodeTracePuzzle implements the "predict the output of a program" challenge
+type CodeTracePuzzle struct{}
+
+// NewCodeTracePuzzle creates a new code trace puzzle instance
+func NewCodeTracePuzzle() *CodeTracePuzzle {
+	return &CodeTracePuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *CodeTracePuzzle) Name() string {
+	return "codetrace"
+}
+
+// Generate creates a new code tracing challenge
+func (p *CodeTracePuzzle) Generate() (instructions string, state any) {
+	program, output := generateProgram()
+
+	instructions = fmt.Sprintf(`Trace this Python program and state exactly what it prints:
+
+%s
+Answer with the printed output on a single line, values separated by spaces.`,
+		renderProgram(program))
+
+	return instructions, CodeTraceState{Output: output}
+}
+
+// Validate checks if the answer matches the printed output, ignoring
+// case, quotes, commas and extra whitespace
+func (p *CodeTracePuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(CodeTraceState)
+	if !ok {
+		return false
+	}
+	return outputTokens(answer) == outputTokens(s.Output)
+}
+
+var outputNoise = strings.NewReplacer(",", " ", `"`, "", "'", "", "`", "", "(", " ", ")", " ")
+
+func outputTokens(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(outputNoise.Replace(s)), " "))
+}
+
+// ctBlock builds a few statements that leave their result in the returned variable
+type ctBlock func(name string) []ctStmt
+
+// ctBlocks pairs each block builder with the variable names it may use for its result
+var ctBlocks = []struct {
+	build ctBlock
+	names []string
+}{
+	{accumulateBlock, []string{"total", "acc", "score"}},
+	{collatzBlock, []string{"steps", "hops", "moves"}},
+	{digitSumBlock, []string{"digits", "dsum", "checksum"}},
+	{pickLettersBlock, []string{"out", "picked", "code"}},
+	{sliceBlock, []string{"mix", "part", "piece"}},
+	{countLettersBlock, []string{"tally", "hits", "found"}},
+	{fibonacciBlock, []string{"fib", "seq", "last"}},
+}
+
+// generateProgram combines two random blocks and prints their results.
+// Programs that fail to run or print nothing are regenerated.
+func generateProgram() ([]ctStmt, string) {
+	for {
+		perm := rand.Perm(len(ctBlocks))
+		var program []ctStmt
+		var printed []ctExpr
+		for _, i := range perm[:2] {
+			b := ctBlocks[i]
+			name := b.names[rand.Intn(len(b.names))]
+			program = append(program, b.build(name)...)
+			printed = append(printed, ctVar{name})
+		}
+		program = append(program, ctPrint{args: printed})
+
+		out, err := runProgram(program)
+		if err != nil || len(out) != 1 || strings.TrimSpace(out[0]) == "" {
+			continue
+		}
+		return program, out[0]
+	}
+}
+
+func num(n int) ctNum { return ctNum{n} }
+
+func bin(op string, l, r ctExpr) ctBin { return ctBin{op: op, l: l, r: r} }
+
+func intPtr(n int) *int { return &n }
+
+// accumulateBlock sums a range with a conditional branch
+func accumulateBlock(name string) []ctStmt {
+	loopVar := pick("i", "k", "n")
+	lo := between(0, 4)
+	return []ctStmt{
+		ctSet(name, num(between(0, 20))),
+		ctFor{
+			v:  loopVar,
+			lo: num(lo),
+			hi: num(lo + between(4, 8)),
+			body: []ctStmt{
+				ctIf{
+					cond: ctCond{op: "==", l: bin("%", ctVar{loopVar}, num(between(2, 4))), r: num(0)},
+					then: []ctStmt{ctSet(name, bin("+", ctVar{name}, bin("*", ctVar{loopVar}, num(between(2, 5)))))},
+					otherwise: []ctStmt{
+						ctSet(name, bin("-", ctVar{name}, num(between(1, 3)))),
+					},
+				},
+			},
+		},
+	}
+}
+
+// collatzBlock counts Collatz steps from a small start value
+func collatzBlock(name string) []ctStmt {
+	v := pick("n", "x", "val")
+	return []ctStmt{
+		ctSet(v, num(between(3, 12))),
+		ctSet(name, num(0)),
+		ctWhile{
+			cond: ctCond{op: "!=", l: ctVar{v}, r: num(1)},
+			body: []ctStmt{
+				ctIf{
+					cond:      ctCond{op: "==", l: bin("%", ctVar{v}, num(2)), r: num(0)},
+					then:      []ctStmt{ctSet(v, bin("//", ctVar{v}, num(2)))},
+					otherwise: []ctStmt{ctSet(v, bin("+", bin("*", num(3), ctVar{v}), num(1)))},
+				},
+				ctSet(name, bin("+", ctVar{name}, num(1))),
+			},
+		},
+	}
+}
+
+// digitSumBlock adds up the digits of a number, optionally weighting them
+func digitSumBlock(name string) []ctStmt {
+	v := pick("m", "rest", "q")
+	weight := between(1, 3)
+	var add ctExpr = bin("%", ctVar{v}, num(10))
+	if weight > 1 {
+		add = bin("*", add, num(weight))
+	}
+	return []ctStmt{
+		ctSet(v, num(between(100, 99999))),
+		ctSet(name, num(0)),
+		ctWhile{
+			cond: ctCond{op: ">", l: ctVar{v}, r: num(0)},
+			body: []ctStmt{
+				ctSet(name, bin("+", ctVar{name}, add)),
+				ctSet(v, bin("//", ctVar{v}, num(10))),
+			},
+		},
+	}
+}
+
+// pickLettersBlock collects every n-th letter of a word
+func pickLettersBlock(name string) []ctStmt {
+	w := pick("word", "text", "src")
+	i := pick("i", "j", "p")
+	word := ChallengeWords[rand.Intn(len(ChallengeWords))]
+	return []ctStmt{
+		ctSet(w, ctText{word}),
+		ctSet(name, ctText{""}),
+		ctFor{
+			v:    i,
+			lo:   num(between(0, 3)),
+			hi:   ctLen{ctVar{w}},
+			step: num(between(3, 5)),
+			body: []ctStmt{ctSet(name, bin("+", ctVar{name}, ctIndex{ctVar{w}, ctVar{i}}))},
+		},
+	}
+}
+
+// sliceBlock glues together slices of a word
+func sliceBlock(name string) []ctStmt {
+	w := pick("s", "name", "label")
+	word := ChallengeWords[rand.Intn(len(ChallengeWords))]
+	lo := between(1, 4)
+	head := ctSlice{s: ctVar{w}, lo: intPtr(lo), hi: intPtr(lo + between(2, 4))}
+	tail := ctSlice{s: ctVar{w}, lo: intPtr(-between(2, 4))}
+	return []ctStmt{
+		ctSet(w, ctText{word}),
+		ctSet(name, bin("+", tail, head)),
+	}
+}
+
+// countLettersBlock counts letters of a word in a dictionary and combines two counts
+func countLettersBlock(name string) []ctStmt {
+	d := pick("counts", "freq", "seen")
+	ch := pick("ch", "c", "letter")
+	word := ChallengeWords[rand.Intn(len(ChallengeWords))]
+	a := word[rand.Intn(len(word))]
+	b := word[rand.Intn(len(word))]
+	return []ctStmt{
+		ctDictInit{d},
+		ctForEach{v: ch, iter: ctText{word}, body: []ctStmt{ctDictAdd{name: d, key: ctVar{ch}, value: num(1)}}},
+		ctSet(name, bin("+",
+			bin("*", ctDictGet{d, ctText{string(a)}}, num(between(2, 10))),
+			ctDictGet{d, ctText{string(b)}})),
+	}
+}
+
+// fibonacciBlock runs a Fibonacci-like recurrence with tuple assignment
+func fibonacciBlock(name string) []ctStmt {
+	other := pick("prev", "a", "older")
+	i := pick("_", "t", "r")
+	return []ctStmt{
+		ctAssign{names: []string{other, name}, values: []ctExpr{num(between(0, 3)), num(between(1, 4))}},
+		ctFor{
+			v:  i,
+			lo: num(0),
+			hi: num(between(3, 7)),
+			body: []ctStmt{
+				ctAssign{names: []string{other, name}, values: []ctExpr{ctVar{name}, bin("+", ctVar{other}, ctVar{name})}},
+			},
+		},
+	}
+}
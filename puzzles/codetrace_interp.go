@@ -0,0 +1,500 @@
+package puzzles
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file holds the tiny Python-like language used by CodeTracePuzzle:
+// an AST that renders itself as source code and evaluates with Python semantics.
+
+// ctMaxSteps bounds the work a generated program may do
+const ctMaxSteps = 10000
+
+// ctValue is an int or a string
+type ctValue struct {
+	isStr bool
+	n     int
+	s     string
+}
+
+func ctIntValue(n int) ctValue    { return ctValue{n: n} }
+func ctStrValue(s string) ctValue { return ctValue{isStr: true, s: s} }
+
+// String formats the value the way Python's print does
+func (v ctValue) String() string {
+	if v.isStr {
+		return v.s
+	}
+	return strconv.Itoa(v.n)
+}
+
+// ctEnv holds variables, dictionaries and printed output during evaluation
+type ctEnv struct {
+	vars  map[string]ctValue
+	dicts map[string]map[string]int
+	out   []string
+	steps int
+	err   error
+}
+
+func newCtEnv() *ctEnv {
+	return &ctEnv{
+		vars:  make(map[string]ctValue),
+		dicts: make(map[string]map[string]int),
+	}
+}
+
+func (env *ctEnv) fail(format string, args ...any) {
+	if env.err == nil {
+		env.err = fmt.Errorf(format, args...)
+	}
+}
+
+// tick counts one step and reports whether evaluation may continue
+func (env *ctEnv) tick() bool {
+	env.steps++
+	if env.steps > ctMaxSteps {
+		env.fail("program exceeded %d steps", ctMaxSteps)
+	}
+	return env.err == nil
+}
+
+// runProgram executes the statements and returns the printed lines
+func runProgram(program []ctStmt) ([]string, error) {
+	env := newCtEnv()
+	execBlock(env, program)
+	return env.out, env.err
+}
+
+// renderProgram returns the source code of the statements
+func renderProgram(program []ctStmt) string {
+	var b strings.Builder
+	for _, st := range program {
+		b.WriteString(st.code("    "))
+	}
+	return b.String()
+}
+
+func execBlock(env *ctEnv, block []ctStmt) {
+	for _, st := range block {
+		if !env.tick() {
+			return
+		}
+		st.exec(env)
+	}
+}
+
+func renderBlock(block []ctStmt, indent string) string {
+	var b strings.Builder
+	for _, st := range block {
+		b.WriteString(st.code(indent))
+	}
+	return b.String()
+}
+
+// Expressions
+
+type ctExpr interface {
+	eval(env *ctEnv) ctValue
+	code() string
+}
+
+type ctNum struct{ n int }
+
+func (e ctNum) eval(env *ctEnv) ctValue { return ctIntValue(e.n) }
+func (e ctNum) code() string            { return strconv.Itoa(e.n) }
+
+type ctText struct{ s string }
+
+func (e ctText) eval(env *ctEnv) ctValue { return ctStrValue(e.s) }
+func (e ctText) code() string            { return strconv.Quote(e.s) }
+
+type ctVar struct{ name string }
+
+func (e ctVar) eval(env *ctEnv) ctValue {
+	v, ok := env.vars[e.name]
+	if !ok {
+		env.fail("undefined variable %s", e.name)
+	}
+	return v
+}
+func (e ctVar) code() string { return e.name }
+
+// ctBin is a binary arithmetic operation; + also concatenates strings
+type ctBin struct {
+	op   string
+	l, r ctExpr
+}
+
+func ctPrecedence(e ctExpr) int {
+	if b, ok := e.(ctBin); ok {
+		if b.op == "+" || b.op == "-" {
+			return 1
+		}
+		return 2
+	}
+	return 3
+}
+
+func (e ctBin) eval(env *ctEnv) ctValue {
+	l, r := e.l.eval(env), e.r.eval(env)
+	if l.isStr || r.isStr {
+		if e.op == "+" && l.isStr && r.isStr {
+			return ctStrValue(l.s + r.s)
+		}
+		env.fail("unsupported operand types for %s", e.op)
+		return ctValue{}
+	}
+	switch e.op {
+	case "+":
+		return ctIntValue(l.n + r.n)
+	case "-":
+		return ctIntValue(l.n - r.n)
+	case "*":
+		return ctIntValue(l.n * r.n)
+	case "//", "%":
+		if r.n == 0 {
+			env.fail("division by zero")
+			return ctValue{}
+		}
+		if e.op == "//" {
+			return ctIntValue(floorDiv(l.n, r.n))
+		}
+		return ctIntValue(l.n - floorDiv(l.n, r.n)*r.n)
+	}
+	env.fail("unknown operator %s", e.op)
+	return ctValue{}
+}
+
+func (e ctBin) code() string {
+	p := ctPrecedence(e)
+	l, r := e.l.code(), e.r.code()
+	if ctPrecedence(e.l) < p {
+		l = "(" + l + ")"
+	}
+	// The right operand also needs parentheses at equal precedence: a - (b - c)
+	if ctPrecedence(e.r) <= p && ctPrecedence(e.r) < 3 {
+		r = "(" + r + ")"
+	}
+	return l + " " + e.op + " " + r
+}
+
+// floorDiv divides rounding toward negative infinity, like Python's //
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// ctIndex picks a single character of a string
+type ctIndex struct{ s, i ctExpr }
+
+func (e ctIndex) eval(env *ctEnv) ctValue {
+	s, i := e.s.eval(env), e.i.eval(env)
+	n := len(s.s)
+	idx := i.n
+	if idx < 0 {
+		idx += n
+	}
+	if !s.isStr || i.isStr || idx < 0 || idx >= n {
+		env.fail("string index out of range")
+		return ctStrValue("")
+	}
+	return ctStrValue(s.s[idx : idx+1])
+}
+func (e ctIndex) code() string { return e.s.code() + "[" + e.i.code() + "]" }
+
+// ctSlice is a Python string slice; nil bounds are omitted
+type ctSlice struct {
+	s      ctExpr
+	lo, hi *int
+}
+
+func (e ctSlice) eval(env *ctEnv) ctValue {
+	s := e.s.eval(env)
+	if !s.isStr {
+		env.fail("slicing a non-string")
+		return ctStrValue("")
+	}
+	n := len(s.s)
+	bound := func(p *int, def int) int {
+		if p == nil {
+			return def
+		}
+		x := *p
+		if x < 0 {
+			x += n
+		}
+		return max(0, min(x, n))
+	}
+	lo, hi := bound(e.lo, 0), bound(e.hi, n)
+	if hi < lo {
+		return ctStrValue("")
+	}
+	return ctStrValue(s.s[lo:hi])
+}
+
+func (e ctSlice) code() string {
+	bound := func(p *int) string {
+		if p == nil {
+			return ""
+		}
+		return strconv.Itoa(*p)
+	}
+	return e.s.code() + "[" + bound(e.lo) + ":" + bound(e.hi) + "]"
+}
+
+type ctLen struct{ e ctExpr }
+
+func (e ctLen) eval(env *ctEnv) ctValue {
+	v := e.e.eval(env)
+	if !v.isStr {
+		env.fail("len of a non-string")
+	}
+	return ctIntValue(len(v.s))
+}
+func (e ctLen) code() string { return "len(" + e.e.code() + ")" }
+
+// ctDictGet reads a dictionary entry with a default of 0
+type ctDictGet struct {
+	name string
+	key  ctExpr
+}
+
+func (e ctDictGet) eval(env *ctEnv) ctValue {
+	d, ok := env.dicts[e.name]
+	if !ok {
+		env.fail("undefined dictionary %s", e.name)
+		return ctIntValue(0)
+	}
+	return ctIntValue(d[e.key.eval(env).String()])
+}
+func (e ctDictGet) code() string { return e.name + ".get(" + e.key.code() + ", 0)" }
+
+// Conditions
+
+type ctCond struct {
+	op   string
+	l, r ctExpr
+}
+
+func (c ctCond) eval(env *ctEnv) bool {
+	l, r := c.l.eval(env), c.r.eval(env)
+	if l.isStr != r.isStr {
+		env.fail("comparing a string with a number")
+		return false
+	}
+	cmp := strings.Compare(l.s, r.s)
+	if !l.isStr {
+		cmp = l.n - r.n
+	}
+	switch c.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	env.fail("unknown comparison %s", c.op)
+	return false
+}
+func (c ctCond) code() string { return c.l.code() + " " + c.op + " " + c.r.code() }
+
+// Statements
+
+type ctStmt interface {
+	exec(env *ctEnv)
+	code(indent string) string
+}
+
+// ctAssign sets one or more variables at once, like a, b = b, a + b
+type ctAssign struct {
+	names  []string
+	values []ctExpr
+}
+
+func (s ctAssign) exec(env *ctEnv) {
+	values := make([]ctValue, len(s.values))
+	for i, e := range s.values {
+		values[i] = e.eval(env)
+	}
+	for i, name := range s.names {
+		env.vars[name] = values[i]
+	}
+}
+
+func (s ctAssign) code(indent string) string {
+	// Render x = x + 1 as x += 1
+	if len(s.names) == 1 {
+		if b, ok := s.values[0].(ctBin); ok && (b.op == "+" || b.op == "-" || b.op == "*") {
+			if v, ok := b.l.(ctVar); ok && v.name == s.names[0] {
+				return indent + s.names[0] + " " + b.op + "= " + b.r.code() + "\n"
+			}
+		}
+	}
+	values := make([]string, len(s.values))
+	for i, e := range s.values {
+		values[i] = e.code()
+	}
+	return indent + strings.Join(s.names, ", ") + " = " + strings.Join(values, ", ") + "\n"
+}
+
+func ctSet(name string, value ctExpr) ctAssign {
+	return ctAssign{names: []string{name}, values: []ctExpr{value}}
+}
+
+// ctDictInit creates an empty dictionary
+type ctDictInit struct{ name string }
+
+func (s ctDictInit) exec(env *ctEnv)           { env.dicts[s.name] = make(map[string]int) }
+func (s ctDictInit) code(indent string) string { return indent + s.name + " = {}\n" }
+
+// ctDictAdd adds to a dictionary entry, treating missing keys as 0
+type ctDictAdd struct {
+	name       string
+	key, value ctExpr
+}
+
+func (s ctDictAdd) exec(env *ctEnv) {
+	d, ok := env.dicts[s.name]
+	if !ok {
+		env.fail("undefined dictionary %s", s.name)
+		return
+	}
+	v := s.value.eval(env)
+	if v.isStr {
+		env.fail("adding a string to a counter")
+		return
+	}
+	d[s.key.eval(env).String()] += v.n
+}
+
+func (s ctDictAdd) code(indent string) string {
+	key := s.key.code()
+	return fmt.Sprintf("%s%s[%s] = %s.get(%s, 0) + %s\n", indent, s.name, key, s.name, key, s.value.code())
+}
+
+// ctFor loops over range(lo, hi, step)
+type ctFor struct {
+	v            string
+	lo, hi, step ctExpr
+	body         []ctStmt
+}
+
+func (s ctFor) exec(env *ctEnv) {
+	lo, hi, step := s.lo.eval(env).n, s.hi.eval(env).n, 1
+	if s.step != nil {
+		step = s.step.eval(env).n
+	}
+	if step <= 0 {
+		env.fail("range step must be positive")
+		return
+	}
+	for i := lo; i < hi && env.err == nil; i += step {
+		env.vars[s.v] = ctIntValue(i)
+		execBlock(env, s.body)
+	}
+}
+
+func (s ctFor) code(indent string) string {
+	args := s.lo.code() + ", " + s.hi.code()
+	if s.step != nil {
+		args += ", " + s.step.code()
+	}
+	return indent + "for " + s.v + " in range(" + args + "):\n" + renderBlock(s.body, indent+"    ")
+}
+
+// ctForEach loops over the characters of a string
+type ctForEach struct {
+	v    string
+	iter ctExpr
+	body []ctStmt
+}
+
+func (s ctForEach) exec(env *ctEnv) {
+	str := s.iter.eval(env)
+	if !str.isStr {
+		env.fail("iterating over a non-string")
+		return
+	}
+	for _, ch := range str.s {
+		if env.err != nil {
+			return
+		}
+		env.vars[s.v] = ctStrValue(string(ch))
+		execBlock(env, s.body)
+	}
+}
+
+func (s ctForEach) code(indent string) string {
+	return indent + "for " + s.v + " in " + s.iter.code() + ":\n" + renderBlock(s.body, indent+"    ")
+}
+
+type ctWhile struct {
+	cond ctCond
+	body []ctStmt
+}
+
+func (s ctWhile) exec(env *ctEnv) {
+	for env.err == nil && s.cond.eval(env) {
+		if !env.tick() {
+			return
+		}
+		execBlock(env, s.body)
+	}
+}
+
+func (s ctWhile) code(indent string) string {
+	return indent + "while " + s.cond.code() + ":\n" + renderBlock(s.body, indent+"    ")
+}
+
+type ctIf struct {
+	cond            ctCond
+	then, otherwise []ctStmt
+}
+
+func (s ctIf) exec(env *ctEnv) {
+	if s.cond.eval(env) {
+		execBlock(env, s.then)
+	} else {
+		execBlock(env, s.otherwise)
+	}
+}
+
+func (s ctIf) code(indent string) string {
+	out := indent + "if " + s.cond.code() + ":\n" + renderBlock(s.then, indent+"    ")
+	if len(s.otherwise) > 0 {
+		out += indent + "else:\n" + renderBlock(s.otherwise, indent+"    ")
+	}
+	return out
+}
+
+// ctPrint prints its arguments separated by spaces
+type ctPrint struct{ args []ctExpr }
+
+func (s ctPrint) exec(env *ctEnv) {
+	parts := make([]string, len(s.args))
+	for i, e := range s.args {
+		parts[i] = e.eval(env).String()
+	}
+	env.out = append(env.out, strings.Join(parts, " "))
+}
+
+func (s ctPrint) code(indent string) string {
+	args := make([]string, len(s.args))
+	for i, e := range s.args {
+		args[i] = e.code()
+	}
+	return indent + "print(" + strings.Join(args, ", ") + ")\n"
+}
@@ -0,0 +1,310 @@
+package puzzles
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// solveCodeTrace parses the program back into statements and runs them
+func solveCodeTrace(instructions string) (string, bool) {
+	var lines []string
+	for _, line := range strings.Split(instructions, "\n") {
+		if code, ok := strings.CutPrefix(line, "    "); ok {
+			lines = append(lines, code)
+		}
+	}
+	program, rest, err := parseCtBlock(lines, "")
+	if err != nil || len(rest) > 0 {
+		return "", false
+	}
+	out, err := runProgram(program)
+	if err != nil || len(out) != 1 {
+		return "", false
+	}
+	return out[0], true
+}
+
+// parseCtBlock parses the statements at the given indentation and returns the
+// lines after the block
+func parseCtBlock(lines []string, indent string) ([]ctStmt, []string, error) {
+	var block []ctStmt
+	for len(lines) > 0 {
+		line, ok := strings.CutPrefix(lines[0], indent)
+		if !ok || strings.HasPrefix(line, " ") || line == "else:" {
+			break
+		}
+		lines = lines[1:]
+
+		var st ctStmt
+		var err error
+		if header, isBlock := strings.CutSuffix(line, ":"); isBlock {
+			var body []ctStmt
+			body, lines, err = parseCtBlock(lines, indent+"    ")
+			if err != nil {
+				return nil, nil, err
+			}
+			st, err = parseCtHeader(header, body)
+			if cond, isIf := st.(ctIf); isIf && err == nil && len(lines) > 0 && lines[0] == indent+"else:" {
+				cond.otherwise, lines, err = parseCtBlock(lines[1:], indent+"    ")
+				st = cond
+			}
+		} else {
+			st, err = parseCtStmt(line)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		block = append(block, st)
+	}
+	return block, lines, nil
+}
+
+// parseCtHeader parses the first line of a for, while or if statement
+func parseCtHeader(header string, body []ctStmt) (ctStmt, error) {
+	p := newCtParser(header)
+	switch p.next() {
+	case "for":
+		v := p.next()
+		if p.next() != "in" {
+			return nil, fmt.Errorf("bad for loop %q", header)
+		}
+		if p.peek() == "range" {
+			p.next()
+			args := p.callArgs()
+			if len(args) < 2 || len(args) > 3 {
+				return nil, fmt.Errorf("bad range %q", header)
+			}
+			loop := ctFor{v: v, lo: args[0], hi: args[1], body: body}
+			if len(args) == 3 {
+				loop.step = args[2]
+			}
+			return loop, p.done()
+		}
+		return ctForEach{v: v, iter: p.expr(), body: body}, p.done()
+	case "while":
+		return ctWhile{cond: p.cond(), body: body}, p.done()
+	case "if":
+		return ctIf{cond: p.cond(), then: body}, p.done()
+	}
+	return nil, fmt.Errorf("unknown block %q", header)
+}
+
+// parseCtStmt parses an assignment, a dictionary update or a print
+func parseCtStmt(line string) (ctStmt, error) {
+	if args, ok := strings.CutPrefix(line, "print"); ok {
+		p := newCtParser(args)
+		return ctPrint{args: p.callArgs()}, p.done()
+	}
+	target, value, ok := strings.Cut(line, " = ")
+	if !ok {
+		// Augmented assignment such as x += 1
+		for _, op := range []string{"+", "-", "*"} {
+			if name, value, ok := strings.Cut(line, " "+op+"= "); ok {
+				p := newCtParser(value)
+				return ctSet(name, bin(op, ctVar{name}, p.expr())), p.done()
+			}
+		}
+		return nil, fmt.Errorf("unknown statement %q", line)
+	}
+	if value == "{}" {
+		return ctDictInit{target}, nil
+	}
+	if name, _, isDict := strings.Cut(target, "["); isDict {
+		// d[k] = d.get(k, 0) + v
+		p := newCtParser(value)
+		sum, ok := p.expr().(ctBin)
+		get, isGet := sum.l.(ctDictGet)
+		if !ok || !isGet || sum.op != "+" {
+			return nil, fmt.Errorf("unknown dictionary update %q", line)
+		}
+		return ctDictAdd{name: name, key: get.key, value: sum.r}, p.done()
+	}
+	p := newCtParser(value)
+	values := []ctExpr{p.expr()}
+	for p.peek() == "," {
+		p.next()
+		values = append(values, p.expr())
+	}
+	return ctAssign{names: strings.Split(target, ", "), values: values}, p.done()
+}
+
+var ctToken = regexp.MustCompile(`\s*("(?:[^"\\]|\\.)*"|\d+|\w+|//|==|!=|<=|>=|\S)`)
+
+// ctParser reads expressions from the tokens of one line
+type ctParser struct {
+	tokens []string
+	err    error
+}
+
+func newCtParser(s string) *ctParser {
+	p := &ctParser{}
+	for _, m := range ctToken.FindAllStringSubmatch(s, -1) {
+		p.tokens = append(p.tokens, m[1])
+	}
+	return p
+}
+
+func (p *ctParser) peek() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+	return p.tokens[0]
+}
+
+func (p *ctParser) next() string {
+	t := p.peek()
+	if len(p.tokens) > 0 {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+func (p *ctParser) expect(t string) {
+	if got := p.next(); got != t && p.err == nil {
+		p.err = fmt.Errorf("expected %q, got %q", t, got)
+	}
+}
+
+// done reports a parse error or tokens left over
+func (p *ctParser) done() error {
+	if p.err == nil && len(p.tokens) > 0 {
+		p.err = fmt.Errorf("unexpected %q", p.tokens[0])
+	}
+	return p.err
+}
+
+func (p *ctParser) callArgs() []ctExpr {
+	p.expect("(")
+	var args []ctExpr
+	for p.err == nil && p.peek() != ")" {
+		args = append(args, p.expr())
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.expect(")")
+	return args
+}
+
+func (p *ctParser) cond() ctCond {
+	l := p.expr()
+	op := p.next()
+	return ctCond{op: op, l: l, r: p.expr()}
+}
+
+// expr parses sums, which bind looser than products
+func (p *ctParser) expr() ctExpr {
+	e := p.term()
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.next()
+		e = bin(op, e, p.term())
+	}
+	return e
+}
+
+func (p *ctParser) term() ctExpr {
+	e := p.primary()
+	for p.peek() == "*" || p.peek() == "//" || p.peek() == "%" {
+		op := p.next()
+		e = bin(op, e, p.primary())
+	}
+	return e
+}
+
+func (p *ctParser) primary() ctExpr {
+	t := p.next()
+	var e ctExpr
+	switch {
+	case t == "(":
+		e = p.expr()
+		p.expect(")")
+	case t == "-":
+		// Negative numbers only occur as slice bounds
+		n, err := strconv.Atoi(p.next())
+		if err != nil && p.err == nil {
+			p.err = err
+		}
+		e = ctNum{-n}
+	case strings.HasPrefix(t, `"`):
+		s, err := strconv.Unquote(t)
+		if err != nil && p.err == nil {
+			p.err = err
+		}
+		e = ctText{s}
+	case t == "len":
+		args := p.callArgs()
+		if len(args) != 1 {
+			p.err = fmt.Errorf("len takes one argument")
+			return ctNum{}
+		}
+		e = ctLen{args[0]}
+	case t != "" && t[0] >= '0' && t[0] <= '9':
+		n, _ := strconv.Atoi(t)
+		e = ctNum{n}
+	case t != "" && (t[0] == '_' || t[0] >= 'a' && t[0] <= 'z' || t[0] >= 'A' && t[0] <= 'Z'):
+		if p.peek() == "." {
+			// d.get(k, 0)
+			p.next()
+			p.expect("get")
+			args := p.callArgs()
+			if len(args) != 2 {
+				p.err = fmt.Errorf("get takes two arguments")
+				return ctNum{}
+			}
+			return ctDictGet{name: t, key: args[0]}
+		}
+		e = ctVar{t}
+	default:
+		if p.err == nil {
+			p.err = fmt.Errorf("unexpected %q", t)
+		}
+		return ctNum{}
+	}
+	for p.peek() == "[" {
+		p.next()
+		e = p.subscript(e)
+	}
+	return e
+}
+
+// subscript parses s[i], s[lo:hi], s[lo:] or s[:hi] after the opening bracket
+func (p *ctParser) subscript(s ctExpr) ctExpr {
+	bound := func() *int {
+		if p.peek() == ":" || p.peek() == "]" {
+			return nil
+		}
+		sign := 1
+		if p.peek() == "-" {
+			p.next()
+			sign = -1
+		}
+		n, err := strconv.Atoi(p.next())
+		if err != nil && p.err == nil {
+			p.err = err
+		}
+		return intPtr(sign * n)
+	}
+	if p.peek() != ":" {
+		// Indexes may be variables, slice bounds are always numbers
+		i := p.expr()
+		if p.peek() != ":" {
+			p.expect("]")
+			return ctIndex{s: s, i: i}
+		}
+		n, ok := i.(ctNum)
+		if !ok {
+			p.err = fmt.Errorf("slice bound must be a number")
+			return s
+		}
+		p.next()
+		hi := bound()
+		p.expect("]")
+		return ctSlice{s: s, lo: intPtr(n.n), hi: hi}
+	}
+	p.next()
+	hi := bound()
+	p.expect("]")
+	return ctSlice{s: s, hi: hi}
+}
@@ -0,0 +1,26 @@
+package puzzles
+
+import "testing"
+
+func TestCodeTraceAnswerFormats(t *testing.T) {
+	p := NewCodeTracePuzzle()
+	state := CodeTraceState{Output: "ace 12"}
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"ace 12", true},
+		{"ACE 12", true},
+		{`"ace", 12`, true},
+		{"(ace, 12)", true},
+		{"  ace   12 ", true},
+		{"ace12", false},
+		{"ace 13", false},
+		{"12 ace", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.answer, got, tt.want)
+		}
+	}
+}
@@ -1,66 +1,91 @@
 package puzzles
 
 import (
+	_ "embed"
+	"log"
 	"math/rand"
 	"strings"
+	"sync"
+
+	"botcha/challenge"
 )
 
-// Shared constants for word scramble puzzles
+// Shared defaults for word scramble puzzles. MinRivals is how many other
+// corpus words must be spelled from the scrambled letters, so that guessing
+// anagrams takes more attempts than allowed.
 const (
 	HiddenPositions = 2
 	ExtraLetters    = 5
+	MinRivals       = 4
 )
 
-// ChallengeWords is the shared list of words used by scramble-based puzzles
-var ChallengeWords = []string{
-	"constantinople",
-	"flabbergasted",
-	"discombobulated",
-	"onomatopoeia",
-	"metamorphosis",
-	"philanthropist",
-	"extraterrestrial",
-	"bioluminescence",
-	"circumnavigation",
-	"perpendicular",
-	"gubernatorial",
-	"idiosyncratic",
-	"quintessential",
-	"czechoslovakia",
-	"enthusiastically",
-	"incomprehensible",
-	"procrastination",
-	"anthropomorphic",
-	"compartmentalize",
-	"differentiation",
-	"electromagnetic",
-	"fundamentalism",
-	"hallucination",
-	"jurisprudence",
-	"knowledgeable",
-	"mediterranean",
-	"neuroscientist",
-	"organizational",
-	"photosynthesis",
-	"questionnaire",
-	"reconnaissance",
-	"rehabilitation",
-	"sophisticated",
-	"telecommunications",
-	"underestimating",
-	"ventriloquist",
-	"weightlessness",
-	"autobiographical",
-	"disproportionate",
-	"counterproductive",
-	"microorganisms",
-	"prestidigitation",
-}
-
-// ScrambleWord shuffles a word, adds extra random letters, and returns the
+// Word lengths accepted from the corpus. Scrambled positions are given as
+// number clues, which go up to 25, so the longest word leaves room for 7 extra letters.
+const (
+	minCorpusWordLength = 8
+	maxCorpusWordLength = 18
+)
+
+//go:embed words.txt
+var wordCorpus string
+
+// ChallengeWords is the shared corpus used by scramble-based puzzles, loaded
+// from the embedded words.txt
+var ChallengeWords = loadWords(wordCorpus)
+
+// wordsByLength indexes ChallengeWords by length for length-balanced sampling
+var wordsByLength = groupByLength(ChallengeWords)
+
+// loadWords reads one word per line, skipping comments, duplicates and words
+// that are not lowercase letters within the accepted lengths
+func loadWords(corpus string) []string {
+	var words []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(corpus, "\n") {
+		w := strings.TrimSpace(line)
+		if w == "" || strings.HasPrefix(w, "#") || seen[w] {
+			continue
+		}
+		if len(w) < minCorpusWordLength || len(w) > maxCorpusWordLength || strings.Trim(w, "abcdefghijklmnopqrstuvwxyz") != "" {
+			log.Printf("Word corpus: skipping %q", w)
+			continue
+		}
+		seen[w] = true
+		words = append(words, w)
+	}
+	return words
+}
+
+func groupByLength(words []string) map[int][]string {
+	groups := make(map[int][]string)
+	for _, w := range words {
+		groups[len(w)] = append(groups[len(w)], w)
+	}
+	return groups
+}
+
+// matchesWord is the answer check shared by the word puzzles: a case-insensitive
+// comparison with the expected word
+func matchesWord(answer, word string) bool {
+	return strings.EqualFold(answer, word)
+}
+
+// ScrambleWord shuffles a word, adds ExtraLetters decoy letters, and returns the
 // scrambled version along with the descramble sequence (1-indexed positions)
 func ScrambleWord(word string) (string, []int) {
-	runes := []rune(strings.ToLower(word))
+	return ScrambleWordExtra(word, ExtraLetters)
+}
+
+// ScrambleWordExtra is like ScrambleWord but adds extraLetters decoy letters
+func ScrambleWordExtra(word string, extraLetters int) (string, []int) {
+	word = strings.ToLower(word)
+	return scrambleWithDecoys(word, WordDecoys(word, extraLetters))
+}
+
+// scrambleWithDecoys shuffles a lowercase word and inserts the decoy letters at
+// random positions
+func scrambleWithDecoys(word string, decoys []rune) (string, []int) {
+	runes := []rune(word)
 	n := len(runes)
 
 	// Track where each original position ends up
@@ -98,9 +123,8 @@ func ScrambleWord(word string) (string, []int) {
 		descrambleSeq[originalPos] = scrambledPos + 1 // 1-indexed
 	}
 
-	// Add extra random letters to increase difficulty
-	for range ExtraLetters {
-		letter := rune('a' + rand.Intn(26))
+	// Add extra letters to increase difficulty
+	for _, letter := range decoys {
 		pos := rand.Intn(len(runes) + 1)
 
 		runes = append(runes[:pos], append([]rune{letter}, runes[pos:]...)...)
@@ -115,3 +139,198 @@ func ScrambleWord(word string) (string, []int) {
 	return string(runes), descrambleSeq
 }
 
+// hidePositions picks count random entries of a descramble sequence to hide.
+// Choices that let another corpus word fit the revealed letters plus unused
+// letters of the scrambled string are skipped, so the answer stays unique. It
+// reports false if no choice of exactly count entries works; callers then draw
+// another word.
+func hidePositions(word, scrambled string, seq []int, count int) (map[int]bool, bool) {
+	if count > len(seq) {
+		return nil, false
+	}
+	for range 20 {
+		hidden := make(map[int]bool, count)
+		for _, i := range rand.Perm(len(seq))[:count] {
+			hidden[i] = true
+		}
+		if !ambiguousHidden(word, scrambled, seq, hidden) {
+			return hidden, true
+		}
+	}
+	return nil, false
+}
+
+// ambiguousHidden reports whether a corpus word other than word matches it at
+// every revealed position and can take its hidden letters from the letters of
+// scrambled that no revealed position uses
+func ambiguousHidden(word, scrambled string, seq []int, hidden map[int]bool) bool {
+	used := make(map[int]bool)
+	for i, pos := range seq {
+		if !hidden[i] {
+			used[pos] = true
+		}
+	}
+	var unused []byte
+	for pos := 1; pos <= len(scrambled); pos++ {
+		if !used[pos] {
+			unused = append(unused, scrambled[pos-1])
+		}
+	}
+
+	for _, w := range wordsByLength[len(word)] {
+		if w == word {
+			continue
+		}
+		fits := true
+		var needed []byte
+		for i := range len(w) {
+			if hidden[i] {
+				needed = append(needed, w[i])
+			} else if w[i] != word[i] {
+				fits = false
+				break
+			}
+		}
+		if fits && len(missingLetters(string(unused), string(needed))) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mentions reports whether text contains word, ignoring case. Generators retry
+// when a clue happens to give the answer away.
+func mentions(text, word string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(word))
+}
+
+// maxRivalDraws bounds how many words are drawn looking for one whose scrambled
+// letters form enough rivals. Strategies such as RandomDecoys rarely form any.
+const maxRivalDraws = 50
+
+// scrambleTuning holds the adjustable difficulty, decoy strategy and rival
+// count shared by scramble-based puzzles
+type scrambleTuning struct {
+	mu         sync.RWMutex
+	difficulty challenge.Difficulty
+	decoys     DecoyStrategy
+	minRivals  int
+}
+
+func newScrambleTuning() scrambleTuning {
+	return scrambleTuning{
+		difficulty: challenge.Difficulty{
+			HiddenPositions: HiddenPositions,
+			MinWordLength:   minCorpusWordLength,
+			ExtraLetters:    ExtraLetters,
+		},
+		decoys:    WordDecoys,
+		minRivals: MinRivals,
+	}
+}
+
+// SetDecoyStrategy replaces the strategy that picks the extra letters of new
+// challenges. RandomDecoys rarely forms rivals, so pair it with SetMinRivals(0).
+func (t *scrambleTuning) SetDecoyStrategy(s DecoyStrategy) {
+	t.mu.Lock()
+	t.decoys = s
+	t.mu.Unlock()
+}
+
+// SetMinRivals sets how many other corpus words must be formable from the
+// scrambled letters. Keep it above the middleware's attempt limit, so that
+// trying every candidate word takes more attempts than allowed. 0 disables
+// the check.
+func (t *scrambleTuning) SetMinRivals(n int) {
+	t.mu.Lock()
+	t.minRivals = max(n, 0)
+	t.mu.Unlock()
+	log.Printf("Minimum rival words: %d", n)
+}
+
+// rivals returns the current minimum number of rival words
+func (t *scrambleTuning) rivals() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.minRivals
+}
+
+// drawScramble picks a word at least minLength letters long and scrambles it
+// with decoys from the current strategy, redrawing until the scrambled
+// letters form at least minRivals other corpus words. Long words rarely have
+// that many rivals, so after every maxRivalDraws failed draws the minimum
+// length drops by one, down to the shortest corpus words, and then each word
+// gets one more decoy letter while the clues can still number every position.
+// Only a strategy that forms no rivals, such as RandomDecoys, ends with a word
+// that has too few.
+func (t *scrambleTuning) drawScramble(minLength, extraLetters int) (word, scrambled string, seq []int) {
+	t.mu.RLock()
+	decoys, minRivals := t.decoys, t.minRivals
+	t.mu.RUnlock()
+
+	for {
+		for range maxRivalDraws {
+			word = pickWord(minLength)
+			extra := min(extraLetters, len(NumberQuestions)-len(word))
+			scrambled, seq = scrambleWithDecoys(word, decoys(word, extra))
+			if formableRivals(word, scrambled) >= minRivals {
+				return word, scrambled, seq
+			}
+		}
+		switch {
+		case minLength > minCorpusWordLength:
+			minLength--
+		case extraLetters < len(NumberQuestions)-minCorpusWordLength:
+			extraLetters++
+		default:
+			log.Printf("Scramble: no word with %d rivals, using %q", minRivals, word)
+			return word, scrambled, seq
+		}
+	}
+}
+
+// formableRivals counts the corpus words other than word, of the same length,
+// that can be spelled from the letters of scrambled
+func formableRivals(word, scrambled string) int {
+	count := 0
+	for _, w := range wordsByLength[len(word)] {
+		if w != word && len(missingLetters(scrambled, w)) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Difficulty returns the current difficulty settings
+func (t *scrambleTuning) Difficulty() challenge.Difficulty {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.difficulty
+}
+
+// SetDifficulty replaces the current difficulty settings
+func (t *scrambleTuning) SetDifficulty(d challenge.Difficulty) {
+	t.mu.Lock()
+	t.difficulty = d
+	t.mu.Unlock()
+}
+
+// pickWord returns a random challenge word at least minLength letters long,
+// falling back to the longest words if none qualify. It first picks a length
+// uniformly, so long words come up as often as short ones even though the
+// corpus has fewer of them.
+func pickWord(minLength int) string {
+	var lengths []int
+	longest := 0
+	for n := range wordsByLength {
+		if n >= minLength {
+			lengths = append(lengths, n)
+		}
+		longest = max(longest, n)
+	}
+	if len(lengths) == 0 {
+		lengths = []int{longest}
+	}
+	words := wordsByLength[lengths[rand.Intn(len(lengths))]]
+	return words[rand.Intn(len(words))]
+}
@@ -0,0 +1,173 @@
+package puzzles
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+)
+
+// defaultConversionTolerance is the relative error accepted in answers
+const defaultConversionTolerance = 0.01
+
+// ConversionState holds the puzzle state stored in the session
+type ConversionState struct {
+	Answer float64
+}
+
+// ConversionPuzzle implements the multi-hop unit conversion challenge
+type ConversionPuzzle struct {
+	mu        sync.Mutex
+	tolerance float64
+}
+
+// NewConversionPuzzle creates a new conversion puzzle instance
+func NewConversionPuzzle() *ConversionPuzzle {
+	return &ConversionPuzzle{tolerance: defaultConversionTolerance}
+}
+
+// Name returns the puzzle identifier
+func (p *ConversionPuzzle) Name() string {
+	return "conversion"
+}
+
+// SetTolerance sets the relative error accepted in answers (0.01 means 1%)
+func (p *ConversionPuzzle) SetTolerance(relative float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tolerance = max(relative, 0)
+}
+
+// unit is a unit of measurement with its size in the base unit of its dimension
+type unit struct {
+	plural string
+	size   float64
+}
+
+// unitTables groups units by dimension; base units are seconds, metres and grams
+var unitTables = [][]unit{
+	{
+		{"seconds", 1},
+		{"minutes", 60},
+		{"hours", 3600},
+		{"days", 86400},
+		{"weeks", 604800},
+		{"fortnights", 1209600},
+	},
+	{
+		{"inches", 0.0254},
+		{"feet", 0.3048},
+		{"yards", 0.9144},
+		{"miles", 1609.344},
+		{"centimetres", 0.01},
+		{"metres", 1},
+		{"kilometres", 1000},
+	},
+	{
+		{"ounces", 28.349523125},
+		{"pounds", 453.59237},
+		{"stone", 6350.29318},
+		{"grams", 1},
+		{"kilograms", 1000},
+	},
+}
+
+// unitFact is a well-known whole-number relation between two units
+type unitFact struct {
+	many, one string
+	value     int
+}
+
+var unitFacts = []unitFact{
+	{"pounds", "a stone", 14},
+	{"ounces", "a pound", 16},
+	{"feet", "a yard", 3},
+	{"inches", "a foot", 12},
+	{"yards", "a mile", 1760},
+	{"days", "a week", 7},
+	{"days", "a fortnight", 14},
+	{"hours", "a day", 24},
+	{"minutes", "an hour", 60},
+	{"grams", "a kilogram", 1000},
+	{"metres", "a kilometre", 1000},
+}
+
+// Generate creates a new conversion challenge
+func (p *ConversionPuzzle) Generate() (instructions string, state any) {
+	for {
+		table := unitTables[rand.Intn(len(unitTables))]
+		perm := rand.Perm(len(table))
+		from, to := table[perm[0]], table[perm[1]]
+
+		// Aim for a handful of target units, then round the amount to something readable
+		amount := roundAmount(float64(between(2, 60)) * to.size / from.size)
+		converted := amount * from.size / to.size
+
+		fact := unitFacts[rand.Intn(len(unitFacts))]
+		factPhrase := fmt.Sprintf("the number of %s in %s", fact.many, fact.one)
+
+		var question string
+		var answer float64
+		if rand.Intn(2) == 0 {
+			question = fmt.Sprintf("How many %s are in %s %s, multiplied by %s?",
+				to.plural, formatAmount(amount), from.plural, factPhrase)
+			answer = converted * float64(fact.value)
+		} else {
+			question = fmt.Sprintf("Take %s, convert it to %s, and divide the result by %s. What number do you get?",
+				formatAmount(amount)+" "+from.plural, to.plural, factPhrase)
+			answer = converted / float64(fact.value)
+		}
+
+		// Two-decimal answers below 1 leave too little to check: "0" would pass
+		// the rounding margin for the smallest ones
+		if answer < 1 {
+			continue
+		}
+
+		instructions = fmt.Sprintf(`Work out this unit conversion:
+
+%s
+
+Use exact definitions (1 inch = 2.54 cm, 1 pound = 453.59237 g).
+Answer with a number; two decimal places are enough.`, question)
+
+		return instructions, ConversionState{Answer: answer}
+	}
+}
+
+// Validate checks if the answer is within the configured tolerance of the expected value
+func (p *ConversionPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(ConversionState)
+	if !ok {
+		return false
+	}
+	got, ok := parseFloatAnswer(answer)
+	if !ok {
+		return false
+	}
+	p.mu.Lock()
+	tolerance := p.tolerance
+	p.mu.Unlock()
+
+	// Two-decimal rounding of small answers can exceed a relative tolerance; the
+	// extra margin keeps answers exactly half a hundredth off from failing on float error
+	allowed := max(math.Abs(s.Answer)*tolerance, 0.005+1e-9)
+	return math.Abs(got-s.Answer) <= allowed
+}
+
+// roundAmount keeps whole numbers for larger amounts and one decimal for small ones
+func roundAmount(x float64) float64 {
+	switch {
+	case x >= 10:
+		return math.Round(x)
+	case x >= 1:
+		return math.Round(x*10) / 10
+	default:
+		return math.Max(math.Round(x*100)/100, 0.01)
+	}
+}
+
+func formatAmount(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package puzzles
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Patterns for the two question templates, used by solveConversion
+var (
+	multiplyPattern = regexp.MustCompile(`How many (\w+) are in ([\d.]+) (\w+), multiplied by the number of (\w+) in (.+)\?`)
+	dividePattern   = regexp.MustCompile(`Take ([\d.]+) (\w+), convert it to (\w+), and divide the result by the number of (\w+) in (.+)\. What`)
+)
+
+// solveConversion is the reference solver: it converts with the unit tables and looks up
+// the unit fact
+func solveConversion(instructions string) (string, bool) {
+	var amount, from, to, many, one string
+	multiply := true
+	if m := multiplyPattern.FindStringSubmatch(instructions); m != nil {
+		to, amount, from, many, one = m[1], m[2], m[3], m[4], m[5]
+	} else if m := dividePattern.FindStringSubmatch(instructions); m != nil {
+		amount, from, to, many, one = m[1], m[2], m[3], m[4], m[5]
+		multiply = false
+	} else {
+		return "", false
+	}
+
+	x, err := strconv.ParseFloat(amount, 64)
+	fromSize, toSize := unitSize(from), unitSize(to)
+	if err != nil || fromSize == 0 || toSize == 0 {
+		return "", false
+	}
+	value := 0
+	for _, fact := range unitFacts {
+		if fact.many == many && fact.one == one {
+			value = fact.value
+		}
+	}
+	if value == 0 {
+		return "", false
+	}
+
+	answer := x * fromSize / toSize
+	if multiply {
+		answer *= float64(value)
+	} else {
+		answer /= float64(value)
+	}
+	return strconv.FormatFloat(answer, 'f', 2, 64), true
+}
+
+// unitSize returns the size of a unit in its base unit, or 0 if unknown
+func unitSize(plural string) float64 {
+	for _, table := range unitTables {
+		for _, u := range table {
+			if strings.EqualFold(u.plural, plural) {
+				return u.size
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,28 @@
+package puzzles
+
+import "testing"
+
+func TestConversionAnswerFormats(t *testing.T) {
+	p := NewConversionPuzzle()
+	state := ConversionState{Answer: 250}
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"250", true},
+		{"250.00", true},
+		{"1,250", false},
+		{"252.4", true},
+		{"247.6", true},
+		{"253", false},
+		{"247", false},
+		{"two hundred and fifty", true},
+		{"about two hundred", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.answer, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of answers a date question can have
+const (
+	dateWeekday = "weekday"
+	dateCount   = "count"
+	dateDay     = "date"
+)
+
+// DateState holds the puzzle state stored in the session
+type DateState struct {
+	Kind   string
+	Answer string
+}
+
+// DatePuzzle implements the calendar reasoning challenge
+type DatePuzzle struct{}
+
+// NewDatePuzzle creates a new date puzzle instance
+func NewDatePuzzle() *DatePuzzle {
+	return &DatePuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *DatePuzzle) Name() string {
+	return "date"
+}
+
+// dateQuestions each ask about a random anchor date and return the answer
+var dateQuestions = []func(anchor time.Time) (question, kind, answer string){
+	weekdayAfterQuestion,
+	dateAfterQuestion,
+	weekdayCountQuestion,
+	weekdayOfQuestion,
+}
+
+// Generate creates a new date reasoning challenge
+func (p *DatePuzzle) Generate() (instructions string, state any) {
+	anchor := time.Date(between(1990, 2030), time.Month(between(1, 12)), 1, 0, 0, 0, 0, time.UTC)
+	anchor = anchor.AddDate(0, 0, rand.Intn(daysIn(anchor)))
+
+	question, kind, answer := dateQuestions[rand.Intn(len(dateQuestions))](anchor)
+
+	instructions = fmt.Sprintf(`Answer this calendar question (Gregorian calendar):
+
+%s`, question)
+
+	return instructions, DateState{Kind: kind, Answer: answer}
+}
+
+// Validate checks the answer, accepting full and abbreviated names and ISO dates
+func (p *DatePuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(DateState)
+	if !ok {
+		return false
+	}
+	switch s.Kind {
+	case dateWeekday:
+		day, ok := parseWeekday(answer)
+		return ok && day.String() == s.Answer
+	case dateCount:
+		n, ok := parseIntAnswer(answer)
+		return ok && strconv.Itoa(n) == s.Answer
+	default:
+		t, ok := parseDate(answer)
+		return ok && t.Format(time.DateOnly) == s.Answer
+	}
+}
+
+func weekdayAfterQuestion(anchor time.Time) (string, string, string) {
+	days := between(20, 400)
+	if rand.Intn(3) == 0 {
+		return fmt.Sprintf("What day of the week was it %d days before %s?", days, formatDate(anchor)),
+			dateWeekday, anchor.AddDate(0, 0, -days).Weekday().String()
+	}
+	return fmt.Sprintf("What day of the week is %d days after %s?", days, formatDate(anchor)),
+		dateWeekday, anchor.AddDate(0, 0, days).Weekday().String()
+}
+
+func dateAfterQuestion(anchor time.Time) (string, string, string) {
+	days := between(20, 200)
+	return fmt.Sprintf("What is the date %d days after %s? Include the year.", days, formatDate(anchor)),
+		dateDay, anchor.AddDate(0, 0, days).Format(time.DateOnly)
+}
+
+func weekdayCountQuestion(anchor time.Time) (string, string, string) {
+	first := anchor.AddDate(0, 0, 1-anchor.Day())
+	weekday := time.Weekday(rand.Intn(7))
+	count := 0
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == weekday {
+			count++
+		}
+	}
+	return fmt.Sprintf("How many %ss are there in %s %d?", weekday, first.Month(), first.Year()),
+		dateCount, strconv.Itoa(count)
+}
+
+func weekdayOfQuestion(anchor time.Time) (string, string, string) {
+	return fmt.Sprintf("On what day of the week did %s fall?", formatDate(anchor)),
+		dateWeekday, anchor.Weekday().String()
+}
+
+func formatDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), t.Month(), t.Year())
+}
+
+// daysIn returns the number of days in the month of t
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// parseWeekday finds a single weekday named in the answer, such as "Tuesday",
+// "tue", "Tues." or "It was a Thursday"
+func parseWeekday(answer string) (time.Weekday, bool) {
+	words := strings.FieldsFunc(strings.ToLower(answer), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '.' || r == '!' || r == '"' || r == '\''
+	})
+	// Two-letter abbreviations ("Tu", "Th") are only trusted on their own
+	minLength := 3
+	if len(words) == 1 {
+		minLength = 2
+	}
+
+	found, seen := time.Sunday, false
+	for _, w := range words {
+		day, ok := weekdayByPrefix(w, minLength)
+		if !ok {
+			continue
+		}
+		if seen && day != found {
+			return 0, false
+		}
+		found, seen = day, true
+	}
+	return found, seen
+}
+
+// weekdayByPrefix matches full weekday names, their plurals and unambiguous
+// abbreviations ("wed", "thurs")
+func weekdayByPrefix(w string, minLength int) (time.Weekday, bool) {
+	w = strings.TrimSuffix(w, "s")
+	if len(w) < minLength {
+		return 0, false
+	}
+	match, count := time.Sunday, 0
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.HasPrefix(strings.ToLower(d.String()), w) {
+			match = d
+			count++
+		}
+	}
+	return match, count == 1
+}
+
+// parseDate reads dates with a year, such as "2019-07-18", "18 July 2019",
+// "July 18th, 2019" or "Thu 18 Jul 2019"
+func parseDate(s string) (time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if t, err := time.Parse(time.DateOnly, strings.TrimRight(s, ".")); err == nil {
+		return t, true
+	}
+
+	var month time.Month
+	day, year := 0, 0
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '.' || r == '/' }) {
+		if m, ok := monthByPrefix(w); ok {
+			month = m
+			continue
+		}
+		w = strings.TrimRight(w, "stndrh")
+		n, err := strconv.Atoi(w)
+		switch {
+		case err != nil:
+		case len(w) == 4:
+			year = n
+		case n >= 1 && n <= 31:
+			day = n
+		}
+	}
+	if month == 0 || day == 0 || year == 0 {
+		return time.Time{}, false
+	}
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	// Reject dates that do not exist, such as 31 June
+	if t.Day() != day {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,56 @@
+package puzzles
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Patterns for the questions asked by dateQuestions, used by solveDate
+var (
+	weekdayShiftPattern = regexp.MustCompile(`^What day of the week (?:is|was it) (\d+) days (after|before) (.+)\?$`)
+	dateAfterPattern    = regexp.MustCompile(`^What is the date (\d+) days after (.+)\? Include the year\.$`)
+	weekdayCountPattern = regexp.MustCompile(`^How many (\w+)s are there in (\w+ \d+)\?$`)
+	weekdayOfPattern    = regexp.MustCompile(`^On what day of the week did (.+) fall\?$`)
+)
+
+// solveDate is the reference solver: it recognizes the question and computes the
+// answer with the calendar
+func solveDate(instructions string) (string, bool) {
+	lines := strings.Split(instructions, "\n")
+	question := lines[len(lines)-1]
+
+	if m := weekdayShiftPattern.FindStringSubmatch(question); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		anchor, ok := parseDate(m[3])
+		if m[2] == "before" {
+			days = -days
+		}
+		return anchor.AddDate(0, 0, days).Weekday().String(), ok
+	}
+	if m := dateAfterPattern.FindStringSubmatch(question); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		anchor, ok := parseDate(m[2])
+		return anchor.AddDate(0, 0, days).Format(time.DateOnly), ok
+	}
+	if m := weekdayCountPattern.FindStringSubmatch(question); m != nil {
+		weekday, ok := parseWeekday(m[1])
+		first, found := parseDate("1 " + m[2])
+		if !ok || !found {
+			return "", false
+		}
+		count := 0
+		for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+			if d.Weekday() == weekday {
+				count++
+			}
+		}
+		return strconv.Itoa(count), true
+	}
+	if m := weekdayOfPattern.FindStringSubmatch(question); m != nil {
+		anchor, ok := parseDate(m[1])
+		return anchor.Weekday().String(), ok
+	}
+	return "", false
+}
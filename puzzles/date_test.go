@@ -0,0 +1,37 @@
+package puzzles
+
+import "testing"
+
+func TestDateAnswerFormats(t *testing.T) {
+	p := NewDatePuzzle()
+	tests := []struct {
+		state  DateState
+		answer string
+		want   bool
+	}{
+		{DateState{dateWeekday, "Thursday"}, "Thursday", true},
+		{DateState{dateWeekday, "Thursday"}, "thurs", true},
+		{DateState{dateWeekday, "Thursday"}, "Thu.", true},
+		{DateState{dateWeekday, "Thursday"}, "Th", true},
+		{DateState{dateWeekday, "Thursday"}, "It was a Thursday.", true},
+		{DateState{dateWeekday, "Thursday"}, "Tuesday", false},
+		{DateState{dateWeekday, "Thursday"}, "T", false},
+		{DateState{dateWeekday, "Thursday"}, "Thursday or Friday", false},
+		{DateState{dateCount, "5"}, "5", true},
+		{DateState{dateCount, "5"}, "five", true},
+		{DateState{dateCount, "5"}, "4", false},
+		{DateState{dateDay, "2024-03-09"}, "2024-03-09", true},
+		{DateState{dateDay, "2024-03-09"}, "March 9, 2024", true},
+		{DateState{dateDay, "2024-03-09"}, "9 Mar 2024", true},
+		{DateState{dateDay, "2024-03-09"}, "Sat, 9th March 2024.", true},
+		{DateState{dateDay, "2024-03-09"}, "March 9", false},
+		{DateState{dateDay, "2024-03-09"}, "2024-09-03", false},
+		{DateState{dateDay, "2024-03-09"}, "March 10, 2024", false},
+		{DateState{dateDay, "2024-06-30"}, "June 31, 2024", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(tt.state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%+v, %q) = %t, want %t", tt.state, tt.answer, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package puzzles
+
+import (
+	"math/rand"
+)
+
+// DecoyStrategy picks n extra letters to hide a lowercase word among when it
+// is scrambled
+type DecoyStrategy func(word string, n int) []rune
+
+// RandomDecoys picks uniformly random letters. Rare letters such as q, x and z
+// stand out, and the word is usually the only corpus word its letters can form.
+func RandomDecoys(word string, n int) []rune {
+	letters := make([]rune, n)
+	for i := range letters {
+		letters[i] = rune('a' + rand.Intn(26))
+	}
+	return letters
+}
+
+// WordDecoys picks letters that complete as many other corpus words of the
+// same length as possible, so the scrambled letters fit several candidate
+// answers and only the clues tell them apart. The puzzles redraw words that
+// end up with fewer rivals than SetMinRivals asks for. Each round adds the letters of
+// a rival that needs the fewest; leftover letters follow English letter
+// frequencies so they do not stand out.
+func WordDecoys(word string, n int) []rune {
+	var rivals []string
+	for _, w := range wordsByLength[len(word)] {
+		if w != word && len(missingLetters(word, w)) <= n {
+			rivals = append(rivals, w)
+		}
+	}
+
+	var letters []rune
+	for len(rivals) > 0 && len(letters) < n {
+		pool := word + string(letters)
+		var cheapest []string
+		fewest := n - len(letters) + 1
+		remaining := rivals[:0]
+		for _, w := range rivals {
+			need := len(missingLetters(pool, w))
+			switch {
+			case need == 0:
+				// Already formable
+				continue
+			case need < fewest:
+				fewest, cheapest = need, []string{w}
+			case need == fewest:
+				cheapest = append(cheapest, w)
+			}
+			remaining = append(remaining, w)
+		}
+		rivals = remaining
+		if len(cheapest) == 0 {
+			break
+		}
+		letters = append(letters, missingLetters(pool, cheapest[rand.Intn(len(cheapest))])...)
+	}
+
+	for len(letters) < n {
+		letters = append(letters, frequentLetter())
+	}
+	return letters
+}
+
+// englishLetterFrequency is the approximate share of each letter, a to z, in
+// English text, in tenths of a percent
+var englishLetterFrequency = [26]int{
+	82, 15, 28, 43, 127, 22, 20, 61, 70, 2, 8, 40, 24,
+	67, 75, 19, 1, 60, 63, 91, 28, 10, 24, 2, 20, 1,
+}
+
+// frequentLetter draws a letter weighted by englishLetterFrequency
+func frequentLetter() rune {
+	total := 0
+	for _, f := range englishLetterFrequency {
+		total += f
+	}
+	x := rand.Intn(total)
+	for i, f := range englishLetterFrequency {
+		if x < f {
+			return rune('a' + i)
+		}
+		x -= f
+	}
+	return 'e'
+}
+
+// missingLetters returns the letters of other, with repeats, that word lacks.
+// Only the lowercase letters a to z are counted.
+func missingLetters(word, other string) []rune {
+	var counts [26]int
+	for _, r := range word {
+		if r >= 'a' && r <= 'z' {
+			counts[r-'a']++
+		}
+	}
+	var missing []rune
+	for _, r := range other {
+		if r >= 'a' && r <= 'z' && counts[r-'a'] > 0 {
+			counts[r-'a']--
+		} else {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,16 @@
+// Package reference hands the reference solvers of the built-in puzzles from
+// package puzzles to puzzletest. Being internal, it keeps them out of the
+// public API of puzzles.
+package reference
+
+// Solver rebuilds the answer of a challenge from its instructions alone
+type Solver func(instructions string) (answer string, ok bool)
+
+// Solvers maps each built-in puzzle name to its reference solver. Package
+// puzzles fills it in when it is initialized.
+var Solvers map[string]Solver
+
+// ReadNumber reads a sequence item of the scramble and charade puzzles: digits,
+// a number word with a letter dropped, a clue from NumberQuestions, or the
+// sum or difference of two clues. Package puzzles fills it in.
+var ReadNumber func(item string) (int, bool)
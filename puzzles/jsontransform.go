@@ -0,0 +1,257 @@
+package puzzles
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// JSONTransformState holds the puzzle state stored in the session
+type JSONTransformState struct {
+	Expected string
+}
+
+// JSONTransformPuzzle implements the structured data transformation challenge
+type JSONTransformPuzzle struct{}
+
+// NewJSONTransformPuzzle creates a new JSON transform puzzle instance
+func NewJSONTransformPuzzle() *JSONTransformPuzzle {
+	return &JSONTransformPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *JSONTransformPuzzle) Name() string {
+	return "jsontransform"
+}
+
+// jsonPerson is one record of the generated document
+type jsonPerson struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	City  string `json:"city"`
+	Score int    `json:"score"`
+}
+
+// jsonTransforms each describe a transformation of the records and compute its result
+var jsonTransforms = []func(people []jsonPerson) (task string, result any, ok bool){
+	olderThanByCity,
+	totalScoreByCity,
+	topScorerInCity,
+	highScorersDescending,
+	countByCity,
+}
+
+// Generate creates a new JSON transformation challenge
+func (p *JSONTransformPuzzle) Generate() (instructions string, state any) {
+	for {
+		people := randomPeople()
+		task, result, ok := jsonTransforms[rand.Intn(len(jsonTransforms))](people)
+		if !ok {
+			continue
+		}
+
+		doc, _ := json.MarshalIndent(people, "", "  ")
+		expected, _ := json.Marshal(result)
+
+		instructions = fmt.Sprintf(`Transform this JSON document:
+
+%s
+
+Task: %s
+
+Answer with the resulting JSON value only.`, doc, task)
+
+		return instructions, JSONTransformState{Expected: string(expected)}
+	}
+}
+
+// Validate compares the answer with the expected JSON value semantically,
+// ignoring key order, whitespace and number formatting
+func (p *JSONTransformPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(JSONTransformState)
+	if !ok {
+		return false
+	}
+	expected, err := decodeJSON(s.Expected)
+	if err != nil {
+		return false
+	}
+	got, err := decodeJSON(answer)
+	if err != nil {
+		// A bare string answer may be given without quotes
+		if str, isStr := expected.(string); isStr {
+			return strings.TrimSpace(answer) == str
+		}
+		return false
+	}
+	return jsonEqual(got, expected)
+}
+
+func decodeJSON(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	// Reject trailing garbage after the value
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// jsonEqual compares decoded JSON values, treating numbers by value
+func jsonEqual(a, b any) bool {
+	switch av := a.(type) {
+	case json.Number:
+		bv, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, err1 := av.Float64()
+		bf, err2 := bv.Float64()
+		return err1 == nil && err2 == nil && af == bf
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			if w, ok := bv[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+var jsonNames = []string{
+	"Ada", "Bela", "Cyrus", "Dana", "Emil", "Fiona", "Goran", "Hana", "Ivo", "Juno",
+	"Kasia", "Liam", "Mila", "Nico", "Olga", "Pavel", "Quinn", "Rosa", "Saul", "Tess",
+}
+
+func randomPeople() []jsonPerson {
+	count := between(6, 9)
+	names := rand.Perm(len(jsonNames))[:count]
+	cities := rand.Perm(len(storyCities))[:3]
+
+	people := make([]jsonPerson, count)
+	for i := range people {
+		people[i] = jsonPerson{
+			Name:  jsonNames[names[i]],
+			Age:   between(10, 40),
+			City:  storyCities[cities[rand.Intn(len(cities))]],
+			Score: between(1, 100),
+		}
+	}
+	return people
+}
+
+func olderThanByCity(people []jsonPerson) (string, any, bool) {
+	threshold := between(12, 20)
+	var older []jsonPerson
+	for _, p := range people {
+		if p.Age > threshold {
+			older = append(older, p)
+		}
+	}
+	if len(older) < 2 {
+		return "", nil, false
+	}
+	sort.Slice(older, func(i, j int) bool {
+		if older[i].City != older[j].City {
+			return older[i].City < older[j].City
+		}
+		return older[i].Name < older[j].Name
+	})
+	names := make([]string, len(older))
+	for i, p := range older {
+		names[i] = p.Name
+	}
+	return fmt.Sprintf("List the names of everyone older than the %s, sorted alphabetically by city and then by name, as a JSON array of strings.",
+		getQuestionForNumber(threshold)), names, true
+}
+
+func totalScoreByCity(people []jsonPerson) (string, any, bool) {
+	totals := make(map[string]int)
+	for _, p := range people {
+		totals[p.City] += p.Score
+	}
+	return "Build a JSON object that maps each city to the total score of the people living there.", totals, true
+}
+
+func topScorerInCity(people []jsonPerson) (string, any, bool) {
+	city := people[rand.Intn(len(people))].City
+	best, count := jsonPerson{Score: -1}, 0
+	for _, p := range people {
+		if p.City != city {
+			continue
+		}
+		if p.Score > best.Score {
+			best, count = p, 1
+		} else if p.Score == best.Score {
+			count++
+		}
+	}
+	if count != 1 {
+		return "", nil, false
+	}
+	return fmt.Sprintf("Return the name of the person with the highest score among those living in %s, as a JSON string.", city),
+		best.Name, true
+}
+
+func highScorersDescending(people []jsonPerson) (string, any, bool) {
+	cutoff := between(3, 7) * 10
+	type entry struct {
+		Name  string `json:"name"`
+		Score int    `json:"score"`
+	}
+	var entries []entry
+	for _, p := range people {
+		if p.Score > cutoff {
+			entries = append(entries, entry{p.Name, p.Score})
+		}
+	}
+	if len(entries) < 2 {
+		return "", nil, false
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return fmt.Sprintf("Return an array of objects with only the name and score of everyone scoring above %d, sorted by score from highest to lowest (ties by name).",
+		cutoff), entries, true
+}
+
+func countByCity(people []jsonPerson) (string, any, bool) {
+	threshold := between(15, 30)
+	counts := make(map[string]int)
+	for _, p := range people {
+		if _, ok := counts[p.City]; !ok {
+			counts[p.City] = 0
+		}
+		if p.Age >= threshold {
+			counts[p.City]++
+		}
+	}
+	return fmt.Sprintf("Build a JSON object that maps every city in the document to the number of its residents aged %d or older (use 0 where there are none).",
+		threshold), counts, true
+}
@@ -0,0 +1,97 @@
+package puzzles
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	olderThanTask  = regexp.MustCompile(`^List the names of everyone older than the (.+), sorted`)
+	topScorerTask  = regexp.MustCompile(`^Return the name of the person with the highest score among those living in (\w+),`)
+	highScoresTask = regexp.MustCompile(`^Return an array of objects with only the name and score of everyone scoring above (\d+),`)
+	countTask      = regexp.MustCompile(`^Build a JSON object that maps every city in the document to the number of its residents aged (\d+) or older`)
+)
+
+// solveJSONTransform decodes the document and carries out the task
+func solveJSONTransform(instructions string) (string, bool) {
+	_, rest, ok := strings.Cut(instructions, "document:\n\n")
+	doc, rest, ok2 := strings.Cut(rest, "\n\nTask: ")
+	task, _, ok3 := strings.Cut(rest, "\n")
+	var people []jsonPerson
+	if !ok || !ok2 || !ok3 || json.Unmarshal([]byte(doc), &people) != nil {
+		return "", false
+	}
+
+	var result any
+	if m := olderThanTask.FindStringSubmatch(task); m != nil {
+		threshold, ok := numberForClue(m[1])
+		if !ok {
+			return "", false
+		}
+		var older []jsonPerson
+		for _, p := range people {
+			if p.Age > threshold {
+				older = append(older, p)
+			}
+		}
+		sort.Slice(older, func(i, j int) bool {
+			if older[i].City != older[j].City {
+				return older[i].City < older[j].City
+			}
+			return older[i].Name < older[j].Name
+		})
+		names := []string{}
+		for _, p := range older {
+			names = append(names, p.Name)
+		}
+		result = names
+	} else if m := topScorerTask.FindStringSubmatch(task); m != nil {
+		best := jsonPerson{Score: -1}
+		for _, p := range people {
+			if p.City == m[1] && p.Score > best.Score {
+				best = p
+			}
+		}
+		result = best.Name
+	} else if m := highScoresTask.FindStringSubmatch(task); m != nil {
+		cutoff, _ := strconv.Atoi(m[1])
+		entries := []map[string]any{}
+		for _, p := range people {
+			if p.Score > cutoff {
+				entries = append(entries, map[string]any{"name": p.Name, "score": p.Score})
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			a, b := entries[i], entries[j]
+			if a["score"] != b["score"] {
+				return a["score"].(int) > b["score"].(int)
+			}
+			return a["name"].(string) < b["name"].(string)
+		})
+		result = entries
+	} else if m := countTask.FindStringSubmatch(task); m != nil {
+		threshold, _ := strconv.Atoi(m[1])
+		counts := make(map[string]int)
+		for _, p := range people {
+			counts[p.City] += 0
+			if p.Age >= threshold {
+				counts[p.City]++
+			}
+		}
+		result = counts
+	} else if strings.HasPrefix(task, "Build a JSON object that maps each city to the total score") {
+		totals := make(map[string]int)
+		for _, p := range people {
+			totals[p.City] += p.Score
+		}
+		result = totals
+	} else {
+		return "", false
+	}
+
+	answer, err := json.Marshal(result)
+	return string(answer), err == nil
+}
@@ -0,0 +1,29 @@
+package puzzles
+
+import "testing"
+
+func TestJSONTransformAnswerFormats(t *testing.T) {
+	p := NewJSONTransformPuzzle()
+	object := JSONTransformState{Expected: `{"name":"Ada","scores":[3,5]}`}
+	text := JSONTransformState{Expected: `"Ada"`}
+	tests := []struct {
+		state  JSONTransformState
+		answer string
+		want   bool
+	}{
+		{object, `{"name":"Ada","scores":[3,5]}`, true},
+		{object, "{\n  \"scores\": [3, 5.0],\n  \"name\": \"Ada\"\n}", true},
+		{object, `{"name":"Ada","scores":[5,3]}`, false},
+		{object, `{"name":"Ada"}`, false},
+		{object, `{"name":"Ada","scores":[3,5],"extra":1}`, false},
+		{object, `{"name":"Ada","scores":[3,5]`, false},
+		{text, `"Ada"`, true},
+		{text, "Ada", true},
+		{text, "ada", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(tt.state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%+v, %q) = %t, want %t", tt.state, tt.answer, got, tt.want)
+		}
+	}
+}
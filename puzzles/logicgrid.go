@@ -0,0 +1,390 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// LogicGridState holds the puzzle state stored in the session. Answers are the
+// two values asked for; Wrong are the other values of their categories.
+type LogicGridState struct {
+	Answers []string
+	Wrong   []string
+}
+
+// LogicGridPuzzle implements the zebra-style logic grid challenge
+type LogicGridPuzzle struct{}
+
+// NewLogicGridPuzzle creates a new logic grid puzzle instance
+func NewLogicGridPuzzle() *LogicGridPuzzle {
+	return &LogicGridPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *LogicGridPuzzle) Name() string {
+	return "logicgrid"
+}
+
+// Generate creates a new logic grid challenge with a unique solution
+func (p *LogicGridPuzzle) Generate() (instructions string, state any) {
+	g := newGrid(between(3, 4))
+	clues := g.minimalClues()
+	question, answers, wrong := g.question(clues)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "There are %d houses in a row, numbered 1 to %d from left to right.\n", g.size, g.size)
+	b.WriteString("Each house has a different color, and each owner has a different name, pet and drink.\n\n")
+	for c, cat := range gridCategories {
+		fmt.Fprintf(&b, "%s: %s\n", cat.title, strings.Join(g.values[c], ", "))
+	}
+	b.WriteString("\nClues:\n")
+	for i, cl := range clues {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, g.clueText(cl))
+	}
+	fmt.Fprintf(&b, "\nQuestion: %s\n\nAnswer with the two values, separated by a comma.", question)
+
+	return b.String(), LogicGridState{Answers: answers, Wrong: wrong}
+}
+
+// Validate checks that the answer names both expected values and no other
+// value of their categories, in any order and wording
+func (p *LogicGridPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(LogicGridState)
+	if !ok || len(s.Answers) == 0 {
+		return false
+	}
+	words := strings.FieldsFunc(strings.ToLower(answer), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, v := range s.Answers {
+		if !slices.Contains(words, strings.ToLower(v)) {
+			return false
+		}
+	}
+	for _, v := range s.Wrong {
+		if slices.Contains(words, strings.ToLower(v)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Attribute categories; the order matches gridCategories
+const (
+	catName = iota
+	catPet
+	catColor
+	catDrink
+	numCategories
+)
+
+var gridCategories = []struct {
+	title  string
+	label  string
+	values []string
+}{
+	{"Names", "name", []string{"Alice", "Bruno", "Chloe", "Dmitri", "Elena", "Farid", "Gwen", "Hugo"}},
+	{"Pets", "pet", []string{"cat", "dog", "fish", "parrot", "hamster", "rabbit", "turtle"}},
+	{"Colors", "house color", []string{"red", "green", "blue", "yellow", "white"}},
+	{"Drinks", "drink", []string{"tea", "coffee", "milk", "juice", "water"}},
+}
+
+// attr identifies one value of one category
+type attr struct {
+	cat, val int
+}
+
+type clueKind int
+
+const (
+	clueSame      clueKind = iota // a and b belong to the same house
+	clueNotSame                   // a and b belong to different houses
+	clueAt                        // a is in house pos
+	clueLeftOf                    // a is directly left of b
+	clueNextTo                    // a and b are neighbours
+	clueSomewhere                 // a is somewhere left of b
+)
+
+type clue struct {
+	kind clueKind
+	a, b attr
+	pos  int
+}
+
+// grid is a random solution: house[c][v] is the house of value v in category c
+type grid struct {
+	size   int
+	values [][]string
+	house  [][]int
+}
+
+func newGrid(size int) *grid {
+	g := &grid{size: size}
+	for _, cat := range gridCategories {
+		perm := rand.Perm(len(cat.values))[:size]
+		vals := make([]string, size)
+		for i, idx := range perm {
+			vals[i] = cat.values[idx]
+		}
+		g.values = append(g.values, vals)
+		g.house = append(g.house, rand.Perm(size))
+	}
+	return g
+}
+
+// holds reports whether a clue is true for the given assignment
+func (cl clue) holds(house [][]int) bool {
+	a := house[cl.a.cat][cl.a.val]
+	b := 0
+	if cl.kind != clueAt {
+		b = house[cl.b.cat][cl.b.val]
+	}
+	switch cl.kind {
+	case clueSame:
+		return a == b
+	case clueNotSame:
+		return a != b
+	case clueAt:
+		return a == cl.pos
+	case clueLeftOf:
+		return a+1 == b
+	case clueNextTo:
+		return a-b == 1 || b-a == 1
+	case clueSomewhere:
+		return a < b
+	}
+	return false
+}
+
+// candidateClues lists true clues about the solution in random order
+func (g *grid) candidateClues() []clue {
+	var all []attr
+	for c := range numCategories {
+		for v := range g.size {
+			all = append(all, attr{c, v})
+		}
+	}
+
+	var clues []clue
+	for _, a := range all {
+		clues = append(clues, clue{kind: clueAt, a: a, pos: g.house[a.cat][a.val]})
+		for _, b := range all {
+			if a.cat == b.cat {
+				continue
+			}
+			for _, kind := range []clueKind{clueSame, clueNotSame, clueLeftOf, clueNextTo, clueSomewhere} {
+				cl := clue{kind: kind, a: a, b: b}
+				if cl.holds(g.house) {
+					clues = append(clues, cl)
+				}
+			}
+		}
+	}
+	rand.Shuffle(len(clues), func(i, j int) { clues[i], clues[j] = clues[j], clues[i] })
+	return clues
+}
+
+// minimalClues adds random true clues until the solution is unique,
+// then drops every clue that is not needed for uniqueness
+func (g *grid) minimalClues() []clue {
+	var clues []clue
+	for _, cl := range g.candidateClues() {
+		clues = append(clues, cl)
+		if g.countSolutions(clues, 2) == 1 {
+			break
+		}
+	}
+	for i := len(clues) - 1; i >= 0; i-- {
+		without := append(append([]clue{}, clues[:i]...), clues[i+1:]...)
+		if g.countSolutions(without, 2) == 1 {
+			clues = without
+		}
+	}
+	return clues
+}
+
+// countSolutions counts assignments that satisfy all clues, stopping at limit
+func (g *grid) countSolutions(clues []clue, limit int) int {
+	return g.searchSolutions(clues, limit, func([][]int) {})
+}
+
+// searchSolutions calls visit with each assignment that satisfies all clues,
+// stopping after limit of them, and returns how many were found
+func (g *grid) searchSolutions(clues []clue, limit int, visit func(house [][]int)) int {
+	perms := permutations(g.size)
+	house := make([][]int, numCategories)
+	count := 0
+
+	var assign func(c int)
+	assign = func(c int) {
+		if count >= limit {
+			return
+		}
+		if c == numCategories {
+			count++
+			visit(house)
+			return
+		}
+		for _, perm := range perms {
+			house[c] = perm
+			ok := true
+			for _, cl := range clues {
+				// Only check clues whose categories are all assigned
+				if cl.a.cat <= c && (cl.kind == clueAt || cl.b.cat <= c) && (cl.a.cat == c || cl.b.cat == c) {
+					if !cl.holds(house) {
+						ok = false
+						break
+					}
+				}
+			}
+			if ok {
+				assign(c + 1)
+			}
+		}
+	}
+	assign(0)
+	return count
+}
+
+// permutations returns all orderings of 0..n-1
+func permutations(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var out [][]int
+	for _, p := range permutations(n - 1) {
+		for i := 0; i <= len(p); i++ {
+			q := make([]int, 0, n)
+			q = append(q, p[:i]...)
+			q = append(q, n-1)
+			q = append(q, p[i:]...)
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// question picks two categories to ask about for the person with a given
+// attribute, such that no single clue links any two of the given attribute
+// and the answers. It returns the question, the two answers and the other
+// values of their categories.
+func (g *grid) question(clues []clue) (string, []string, []string) {
+	for {
+		given := attr{rand.Intn(numCategories), rand.Intn(g.size)}
+		asked := rand.Perm(numCategories)[:2]
+		if slices.Contains(asked, given.cat) {
+			continue
+		}
+		slices.Sort(asked)
+
+		// The person with the given attribute has one value in each asked category
+		linked := []attr{given}
+		var answers, wrong []string
+		for _, c := range asked {
+			for v := range g.size {
+				if g.house[c][v] == g.house[given.cat][given.val] {
+					linked = append(linked, attr{c, v})
+					answers = append(answers, g.values[c][v])
+				} else {
+					wrong = append(wrong, g.values[c][v])
+				}
+			}
+		}
+
+		direct := false
+		for _, cl := range clues {
+			if cl.kind == clueSame && slices.Contains(linked, cl.a) && slices.Contains(linked, cl.b) {
+				direct = true
+			}
+		}
+		if direct {
+			continue
+		}
+
+		return g.questionText(given, asked[0], asked[1]), answers, wrong
+	}
+}
+
+// questionText asks for two categories of the person with the given attribute
+func (g *grid) questionText(given attr, first, second int) string {
+	return fmt.Sprintf("What are the %s and the %s of %s?",
+		gridCategories[first].label, gridCategories[second].label, g.subject(given))
+}
+
+// subject names the person with an attribute at the start of a sentence
+func (g *grid) subject(a attr) string {
+	v := g.values[a.cat][a.val]
+	switch a.cat {
+	case catPet:
+		return "the " + v + " owner"
+	case catColor:
+		return "the person in the " + v + " house"
+	case catDrink:
+		return "the " + v + " drinker"
+	}
+	return v
+}
+
+// object names the person with an attribute after a preposition
+func (g *grid) object(a attr) string {
+	if a.cat == catColor {
+		return "the " + g.values[a.cat][a.val] + " house"
+	}
+	return g.subject(a)
+}
+
+// predicate states that someone has an attribute
+func (g *grid) predicate(a attr) string {
+	v := g.values[a.cat][a.val]
+	switch a.cat {
+	case catPet:
+		return "owns the " + v
+	case catColor:
+		return "lives in the " + v + " house"
+	case catDrink:
+		return "drinks " + v
+	}
+	return "is " + v
+}
+
+// negation states that someone does not have an attribute
+func (g *grid) negation(a attr) string {
+	v := g.values[a.cat][a.val]
+	switch a.cat {
+	case catPet:
+		return "does not own the " + v
+	case catColor:
+		return "does not live in the " + v + " house"
+	case catDrink:
+		return "does not drink " + v
+	}
+	return "is not " + v
+}
+
+var houseOrdinals = []string{"first", "second", "third", "fourth"}
+
+func (g *grid) clueText(cl clue) string {
+	var text string
+	switch cl.kind {
+	case clueSame:
+		text = g.subject(cl.a) + " " + g.predicate(cl.b)
+	case clueNotSame:
+		text = g.subject(cl.a) + " " + g.negation(cl.b)
+	case clueAt:
+		if cl.a.cat == catColor {
+			text = "the " + g.values[cl.a.cat][cl.a.val] + " house is the " + houseOrdinals[cl.pos] + " house"
+		} else {
+			text = g.subject(cl.a) + " lives in the " + houseOrdinals[cl.pos] + " house"
+		}
+	case clueLeftOf:
+		text = g.subject(cl.a) + " lives directly to the left of " + g.object(cl.b)
+	case clueNextTo:
+		text = g.subject(cl.a) + " lives next to " + g.object(cl.b)
+	case clueSomewhere:
+		text = g.subject(cl.a) + " lives somewhere to the left of " + g.object(cl.b)
+	}
+	return strings.ToUpper(text[:1]) + text[1:] + "."
+}
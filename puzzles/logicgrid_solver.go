@@ -0,0 +1,85 @@
+package puzzles
+
+import "strings"
+
+// solveLogicGrid is the reference solver: it maps the clue texts back to clues, finds
+// the unique assignment and reads the two answers from it
+func solveLogicGrid(instructions string) (string, bool) {
+	g := &grid{}
+	for _, cat := range gridCategories {
+		line, ok := instructionLine(instructions, cat.title+": ")
+		if !ok {
+			return "", false
+		}
+		g.values = append(g.values, strings.Split(line, ", "))
+	}
+	g.size = len(g.values[catName])
+
+	// Render every possible clue and question so the texts can be looked up
+	var all []attr
+	for c := range numCategories {
+		for v := range g.size {
+			all = append(all, attr{c, v})
+		}
+	}
+	clueByText := make(map[string]clue)
+	type query struct {
+		given         attr
+		first, second int
+	}
+	queryByText := make(map[string]query)
+	for _, a := range all {
+		for pos := range g.size {
+			cl := clue{kind: clueAt, a: a, pos: pos}
+			clueByText[g.clueText(cl)] = cl
+		}
+		for first := range numCategories {
+			for second := first + 1; second < numCategories; second++ {
+				if first != a.cat && second != a.cat {
+					queryByText[g.questionText(a, first, second)] = query{a, first, second}
+				}
+			}
+		}
+		for _, b := range all {
+			if a.cat == b.cat {
+				continue
+			}
+			for _, kind := range []clueKind{clueSame, clueNotSame, clueLeftOf, clueNextTo, clueSomewhere} {
+				cl := clue{kind: kind, a: a, b: b}
+				clueByText[g.clueText(cl)] = cl
+			}
+		}
+	}
+
+	var clues []clue
+	_, list, _ := strings.Cut(instructions, "\nClues:\n")
+	for _, line := range strings.Split(list, "\n") {
+		_, text, ok := strings.Cut(line, ". ")
+		if !ok {
+			break
+		}
+		cl, ok := clueByText[text]
+		if !ok {
+			return "", false
+		}
+		clues = append(clues, cl)
+	}
+	question, _ := instructionLine(instructions, "Question: ")
+	q, ok := queryByText[question]
+	if !ok {
+		return "", false
+	}
+
+	var answers []string
+	found := g.searchSolutions(clues, 2, func(house [][]int) {
+		answers = nil
+		for _, c := range []int{q.first, q.second} {
+			for v := range g.size {
+				if house[c][v] == house[q.given.cat][q.given.val] {
+					answers = append(answers, g.values[c][v])
+				}
+			}
+		}
+	})
+	return strings.Join(answers, ", "), found == 1
+}
@@ -0,0 +1,28 @@
+package puzzles
+
+import "testing"
+
+func TestLogicGridAnswerFormats(t *testing.T) {
+	p := NewLogicGridPuzzle()
+	state := LogicGridState{Answers: []string{"cat", "Norwegian"}, Wrong: []string{"dog", "fish", "Swede", "Dane"}}
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"cat, Norwegian", true},
+		{"Norwegian, cat", true},
+		{"The Norwegian owns the cat.", true},
+		{"norwegian cat", true},
+		{"cat", false},
+		{"Norwegian", false},
+		{"cat, dog, Norwegian", false},
+		{"Norwegian or Swede, cat", false},
+		{"cats, Norwegians", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.answer, got, tt.want)
+		}
+	}
+}
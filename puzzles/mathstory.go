@@ -0,0 +1,293 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MathStoryState holds the puzzle state stored in the session
+type MathStoryState struct {
+	Answer int
+}
+
+// MathStoryPuzzle implements the arithmetic word problem challenge
+type MathStoryPuzzle struct{}
+
+// NewMathStoryPuzzle creates a new math story puzzle instance
+func NewMathStoryPuzzle() *MathStoryPuzzle {
+	return &MathStoryPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *MathStoryPuzzle) Name() string {
+	return "mathstory"
+}
+
+// Generate creates a new word problem challenge
+func (p *MathStoryPuzzle) Generate() (instructions string, state any) {
+	story, answer := storyGenerators[rand.Intn(len(storyGenerators))]()
+
+	instructions = fmt.Sprintf(`Solve this word problem:
+
+%s
+
+Answer with a single whole number.`, story)
+
+	return instructions, MathStoryState{Answer: answer}
+}
+
+// Validate checks if the answer matches the expected number
+func (p *MathStoryPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(MathStoryState)
+	if !ok {
+		return false
+	}
+	n, ok := parseIntAnswer(answer)
+	return ok && n == s.Answer
+}
+
+var storyNames = []string{
+	"Mara", "Tobias", "Priya", "Jonas", "Aiko", "Felix", "Lena", "Omar",
+	"Greta", "Diego", "Ines", "Kofi", "Nadia", "Rupert", "Sven", "Yara",
+}
+
+var storyCities = []string{
+	"Lisbon", "Oslo", "Krakow", "Lyon", "Porto", "Ghent", "Turin", "Bergen",
+	"Leeds", "Utrecht", "Graz", "Basel", "Malmo", "Seville", "Dresden", "Cork",
+}
+
+var storyWeekdays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// storyGenerators each return a randomized story and its integer answer
+var storyGenerators = []func() (string, int){
+	trainStory,
+	recipeStory,
+	inventoryStory,
+	savingsStory,
+	fleetStory,
+	orchardStory,
+}
+
+func pick(options ...string) string {
+	return options[rand.Intn(len(options))]
+}
+
+func between(lo, hi int) int {
+	return lo + rand.Intn(hi-lo+1)
+}
+
+func trainStory() (string, int) {
+	from, to := pickTwo(storyCities)
+	start := between(40, 180)
+	off1 := between(5, start/3)
+	on1 := between(5, 60)
+	afterFirst := start - off1 + on1
+	// Make sure half of the passengers is a whole number at the second stop
+	if afterFirst%2 == 1 {
+		on1++
+		afterFirst++
+	}
+	on2 := between(3, 40)
+	answer := afterFirst/2 + on2
+
+	story := fmt.Sprintf(pick(
+		"A train bound for %s leaves %s with %d passengers on board. ",
+		"A train heading to %s departs from %s carrying %d passengers. ",
+		"With %[3]d passengers aboard, a train to %[1]s pulls out of %[2]s. ",
+	), to, from, start)
+	story += fmt.Sprintf(pick(
+		"At the first stop, %d passengers get off and %d get on. ",
+		"At the first station %d people leave the train while %d board it. ",
+		"%d travellers step off at the first stop, and %d new ones climb aboard. ",
+	), off1, on1)
+	story += fmt.Sprintf(pick(
+		"At the second stop half of the passengers get off and %d more get on. ",
+		"At the next station exactly half of everyone on board leaves, then %d people board. ",
+		"The second stop sees half the train empty out before %d new passengers join. ",
+	), on2)
+	story += pick(
+		"How many passengers are on the train when it leaves the second stop?",
+		"How many people are on board after the second stop?",
+		"What is the number of passengers on the train after the second station?",
+	)
+	return story, answer
+}
+
+func recipeStory() (string, int) {
+	name := storyNames[rand.Intn(len(storyNames))]
+	type dish struct{ name, ingredient, unit string }
+	dishes := []dish{
+		{"pancakes", "flour", "grams"},
+		{"cookies", "butter", "grams"},
+		{"muffins", "milk", "millilitres"},
+		{"dumplings", "flour", "grams"},
+		{"crepes", "milk", "millilitres"},
+		{"scones", "sugar", "grams"},
+	}
+	d := dishes[rand.Intn(len(dishes))]
+
+	batch := between(4, 12)
+	perBatch := between(3, 30) * 10
+	batches := between(2, 6)
+	have := between(1, perBatch*batches/10-1) * 10
+	answer := perBatch*batches - have
+
+	story := fmt.Sprintf(pick(
+		"A recipe for %d %s needs %d %s of %s. ",
+		"To make %d %s you need %d %s of %s. ",
+		"One batch of %d %s takes %d %s of %s. ",
+	), batch, d.name, perBatch, d.unit, d.ingredient)
+	story += fmt.Sprintf(pick(
+		"%s wants to make %d %s and already has %d %s of %s in the kitchen. ",
+		"%s is baking %d %s for a party and has %d %s of %s at home. ",
+		"%s plans to prepare %d %s; the pantry holds %d %s of %s. ",
+	), name, batch*batches, d.name, have, d.unit, d.ingredient)
+	story += fmt.Sprintf(pick(
+		"How many more %s of %s does %s need to buy?",
+		"How many additional %s of %s must %s get?",
+		"What amount of %s of %s is %s still missing?",
+	), d.unit, d.ingredient, name)
+	return story, answer
+}
+
+func inventoryStory() (string, int) {
+	type item struct{ shop, goods string }
+	items := []item{
+		{"bookshop", "notebooks"},
+		{"hardware store", "hammers"},
+		{"bakery", "loaves"},
+		{"toy shop", "kites"},
+		{"florist", "bouquets"},
+		{"garden centre", "seed packets"},
+	}
+	it := items[rand.Intn(len(items))]
+
+	days := between(3, 6)
+	perDay := between(4, 25)
+	delivery := between(10, 80)
+	start := perDay*days + between(5, 60)
+	deliveryDay := storyWeekdays[rand.Intn(days)]
+	answer := start - perDay*days + delivery
+
+	story := fmt.Sprintf(pick(
+		"A %s starts the week with %d %s in stock. ",
+		"On Monday morning a %s has %d %s on its shelves. ",
+		"At the beginning of the week a %s counts %d %s. ",
+	), it.shop, start, it.goods)
+	story += fmt.Sprintf(pick(
+		"It sells %d %s every day for the first %d days of the week. ",
+		"For %[3]d days in a row it sells %[1]d %[2]s per day. ",
+		"Each of the first %[3]d days, customers buy %[1]d %[2]s. ",
+	), perDay, it.goods, days)
+	story += fmt.Sprintf(pick(
+		"On %s a delivery of %d %s arrives. ",
+		"A supplier drops off %[2]d %[3]s on %[1]s. ",
+		"On %s the shop receives %d more %s. ",
+	), deliveryDay, delivery, it.goods)
+	story += fmt.Sprintf(pick(
+		"How many %s are in stock after those %d days?",
+		"How many %s does the shop have left at the end of day %d?",
+		"What is the number of %s in stock once the %d days are over?",
+	), it.goods, days)
+	return story, answer
+}
+
+func savingsStory() (string, int) {
+	name := storyNames[rand.Intn(len(storyNames))]
+	things := []string{"a bicycle", "a guitar", "a pair of skates", "concert tickets", "a camera", "a tent"}
+
+	weekly := between(5, 40)
+	weeks := between(4, 20)
+	gift := between(1, 10) * 10
+	price := between(weekly, weekly*weeks)
+	answer := weekly*weeks + gift - price
+
+	story := fmt.Sprintf(pick(
+		"%s saves $%d every week for %d weeks. ",
+		"For %[3]d weeks, %[1]s puts $%[2]d a week into a piggy bank. ",
+		"%s sets aside $%d each week, %d weeks in a row. ",
+	), name, weekly, weeks)
+	story += fmt.Sprintf(pick(
+		"A grandparent adds a $%[1]d gift to the savings. ",
+		"On a birthday, %[2]s also receives $%[1]d and saves it too. ",
+		"An extra $%[1]d from selling old books goes into the savings as well. ",
+	), gift, name)
+	story += fmt.Sprintf(pick(
+		"Then %s buys %s for $%d. How many dollars are left?",
+		"%s then spends $%[3]d on %[2]s. How much money, in dollars, remains?",
+		"After that, %s pays $%[3]d for %[2]s. How many dollars does %[1]s have now?",
+	), name, things[rand.Intn(len(things))], price)
+	return story, answer
+}
+
+func fleetStory() (string, int) {
+	city := storyCities[rand.Intn(len(storyCities))]
+	trucks := between(6, 20)
+	crates := between(8, 40)
+	broken := between(1, trucks/2)
+	trips := between(2, 4)
+	answer := (trucks - broken) * crates * trips
+
+	story := fmt.Sprintf(pick(
+		"A depot in %s has %d trucks, and each truck carries %d crates per trip. ",
+		"The %[1]s depot runs %[2]d trucks that can each hold %[3]d crates. ",
+		"In %s, a delivery company owns %d trucks with room for %d crates each. ",
+	), city, trucks, crates)
+	story += fmt.Sprintf(pick(
+		"Today %d trucks are in the repair shop. ",
+		"%d of the trucks broke down this morning. ",
+		"Because of a strike, %d trucks stay in the garage. ",
+	), broken)
+	story += fmt.Sprintf(pick(
+		"Every working truck makes %d trips. How many crates are delivered today?",
+		"Each of the remaining trucks does %d full trips. How many crates get delivered?",
+		"The others complete %d trips each, fully loaded. What is the total number of crates delivered?",
+	), trips)
+	return story, answer
+}
+
+func orchardStory() (string, int) {
+	name := storyNames[rand.Intn(len(storyNames))]
+	fruits := []string{"apples", "pears", "plums", "peaches", "apricots", "lemons"}
+	fruit := fruits[rand.Intn(len(fruits))]
+
+	rows := between(3, 12)
+	perRow := between(4, 15)
+	perTree := between(10, 40)
+	rotten := between(1, 5) * 10
+	answer := rows*perRow*perTree - rotten
+
+	story := fmt.Sprintf(pick(
+		"%s's orchard has %d rows of %s trees with %d trees in each row. ",
+		"%s planted %d rows of %s trees, %d trees per row. ",
+		"In %s's orchard there are %d rows of %s trees, each row holding %d trees. ",
+	), name, rows, singular(fruit), perRow)
+	story += fmt.Sprintf(pick(
+		"Every tree yields %d %s this season, ",
+		"Each tree produces %d %s at harvest, ",
+		"This year, every tree gives %d %s, ",
+	), perTree, fruit)
+	story += fmt.Sprintf(pick(
+		"but %d of them turn out to be rotten. How many good %s are harvested?",
+		"though %d must be thrown away. How many %s are left to sell?",
+		"and %d are eaten by birds before picking. How many %s are picked?",
+	), rotten, fruit)
+	return story, answer
+}
+
+// pickTwo returns two different random entries of a list
+func pickTwo(list []string) (string, string) {
+	perm := rand.Perm(len(list))
+	return list[perm[0]], list[perm[1]]
+}
+
+// singular turns a plural fruit name into the form used before "trees"
+func singular(plural string) string {
+	switch plural {
+	case "peaches":
+		return "peach"
+	case "lemons", "apples", "pears", "plums", "apricots":
+		return plural[:len(plural)-1]
+	}
+	return plural
+}
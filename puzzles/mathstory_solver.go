@@ -0,0 +1,72 @@
+package puzzles
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var storyNumber = regexp.MustCompile(`\$?\d+`)
+
+// solveMathStory recognizes the story by its subject and combines the numbers
+// in the order the sentences give them
+func solveMathStory(instructions string) (string, bool) {
+	var numbers, dollars, plain []int
+	for _, m := range storyNumber.FindAllString(instructions, -1) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(m, "$"))
+		numbers = append(numbers, n)
+		if strings.HasPrefix(m, "$") {
+			dollars = append(dollars, n)
+		} else {
+			plain = append(plain, n)
+		}
+	}
+
+	answer := 0
+	switch {
+	case strings.Contains(instructions, "train"):
+		if len(numbers) != 4 {
+			return "", false
+		}
+		start, off, on, more := numbers[0], numbers[1], numbers[2], numbers[3]
+		answer = (start-off+on)/2 + more
+	case strings.Contains(instructions, "trucks"):
+		if len(numbers) != 4 {
+			return "", false
+		}
+		trucks, crates, broken, trips := numbers[0], numbers[1], numbers[2], numbers[3]
+		answer = (trucks - broken) * crates * trips
+	case strings.Contains(instructions, " trees"):
+		if len(numbers) != 4 {
+			return "", false
+		}
+		rows, perRow, perTree, rotten := numbers[0], numbers[1], numbers[2], numbers[3]
+		answer = rows*perRow*perTree - rotten
+	case len(dollars) > 0:
+		if len(dollars) != 3 || len(plain) != 1 {
+			return "", false
+		}
+		weekly, gift, price := dollars[0], dollars[1], dollars[2]
+		answer = weekly*plain[0] + gift - price
+	case slices.ContainsFunc(storyWeekdays, func(day string) bool { return strings.Contains(instructions, day) }):
+		// Deliveries arrive on a weekday. The question repeats the number of
+		// days; the other number of the selling sentence is the daily amount
+		if len(numbers) != 5 {
+			return "", false
+		}
+		start, a, b, delivery, days := numbers[0], numbers[1], numbers[2], numbers[3], numbers[4]
+		perDay := a
+		if a == days {
+			perDay = b
+		}
+		answer = start - perDay*days + delivery
+	default:
+		if len(numbers) != 4 || numbers[0] == 0 {
+			return "", false
+		}
+		batch, perBatch, total, have := numbers[0], numbers[1], numbers[2], numbers[3]
+		answer = perBatch*(total/batch) - have
+	}
+	return strconv.Itoa(answer), true
+}
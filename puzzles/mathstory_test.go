@@ -0,0 +1,27 @@
+package puzzles
+
+import "testing"
+
+func TestMathStoryAnswerFormats(t *testing.T) {
+	p := NewMathStoryPuzzle()
+	state := MathStoryState{Answer: 42}
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"42", true},
+		{"42.0", true},
+		{" 42. ", true},
+		{"forty-two", true},
+		{"Forty two", true},
+		{"42.5", false},
+		{"43", false},
+		{"four two", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.answer, got, tt.want)
+		}
+	}
+}
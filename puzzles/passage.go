@@ -0,0 +1,235 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of answers a passage question can have
+const (
+	passageNumber = "number"
+	passageDate   = "date"
+	passageName   = "name"
+)
+
+// PassageState holds the puzzle state stored in the session
+type PassageState struct {
+	Kind   string
+	Answer string
+}
+
+// PassagePuzzle implements the reading comprehension challenge
+type PassagePuzzle struct{}
+
+// NewPassagePuzzle creates a new passage puzzle instance
+func NewPassagePuzzle() *PassagePuzzle {
+	return &PassagePuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *PassagePuzzle) Name() string {
+	return "passage"
+}
+
+// passageFacts are the randomized facts a passage is written from
+type passageFacts struct {
+	first, second string
+	town          string
+	festival      string
+	item          string
+
+	firstArrives  time.Time
+	secondArrives time.Time
+	festivalStart time.Time
+	firstLeaves   time.Time
+	secondLeaves  time.Time
+
+	bought, given, boughtMore int
+}
+
+var passageFestivals = []string{"lantern festival", "harvest fair", "music festival", "kite festival", "wine festival", "flower parade"}
+
+var passageItems = []string{"postcards", "candles", "jars of honey", "wooden spoons", "scarves", "paper lanterns"}
+
+func randomPassageFacts() passageFacts {
+	first, second := pickTwo(storyNames)
+	f := passageFacts{
+		first:    first,
+		second:   second,
+		town:     storyCities[rand.Intn(len(storyCities))],
+		festival: passageFestivals[rand.Intn(len(passageFestivals))],
+		item:     passageItems[rand.Intn(len(passageItems))],
+	}
+
+	// A year is needed for date arithmetic but never shown
+	f.firstArrives = time.Date(2023, time.Month(between(1, 12)), between(1, 28), 0, 0, 0, 0, time.UTC)
+	f.secondArrives = f.firstArrives.AddDate(0, 0, between(1, 4))
+	f.festivalStart = f.secondArrives.AddDate(0, 0, between(1, 5))
+	f.firstLeaves = f.festivalStart.AddDate(0, 0, between(1, 6))
+	f.secondLeaves = f.festivalStart.AddDate(0, 0, between(1, 9))
+	for f.secondLeaves.Equal(f.firstLeaves) {
+		f.secondLeaves = f.festivalStart.AddDate(0, 0, between(1, 9))
+	}
+
+	f.bought = between(5, 20)
+	f.given = between(1, f.bought-1)
+	f.boughtMore = between(2, 12)
+	return f
+}
+
+// passageText writes the facts as a paragraph; relative dates force inference
+func (f passageFacts) passageText() string {
+	sentences := []string{
+		fmt.Sprintf(pick(
+			"%s arrived in %s on %s, a few days before the %s.",
+			"On %[3]s, %[1]s reached %[2]s, in time for the %[4]s.",
+			"%s came to %s on %s to see the %s.",
+		), f.first, f.town, formatDayMonth(f.firstArrives), f.festival),
+		fmt.Sprintf(pick(
+			"%s joined %s %s.",
+			"%[3]s, %[1]s followed and met %[2]s at the station.",
+		), f.second, f.first, daysLater(f.secondArrives.Sub(f.firstArrives))),
+		fmt.Sprintf(pick(
+			"The %s began on %s and filled the old town with visitors.",
+			"On %[2]s the %[1]s opened in the main square.",
+		), f.festival, formatDayMonth(f.festivalStart)),
+		fmt.Sprintf(pick(
+			"At the market %s bought %d %s, gave %d of them to %s, and later bought %d more.",
+			"%s picked up %d %s at a stall, handed %d to %s as a gift, then went back for %d more.",
+		), f.first, f.bought, f.item, f.given, f.second, f.boughtMore),
+		fmt.Sprintf(pick(
+			"%s left %s %s after the festival began.",
+			"%[3]s after the start of the festival, %[1]s said goodbye to %[2]s.",
+		), f.first, f.town, dayCount(f.firstLeaves.Sub(f.festivalStart))),
+		fmt.Sprintf(pick(
+			"%s stayed until %s.",
+			"%s caught the train home on %s.",
+		), f.second, formatDayMonth(f.secondLeaves)),
+	}
+	for i, s := range sentences {
+		sentences[i] = strings.ToUpper(s[:1]) + s[1:]
+	}
+	return strings.Join(sentences, " ")
+}
+
+// question picks a question about the facts and returns it with its answer
+func (f passageFacts) question() (question, kind, answer string) {
+	days := func(from, to time.Time) string {
+		return strconv.Itoa(int(to.Sub(from).Hours() / 24))
+	}
+	switch rand.Intn(5) {
+	case 0:
+		return fmt.Sprintf("On what date did %s leave %s?", f.first, f.town),
+			passageDate, formatDayMonth(f.firstLeaves)
+	case 1:
+		return fmt.Sprintf("How many days after the %s began did %s leave?", f.festival, f.second),
+			passageNumber, days(f.festivalStart, f.secondLeaves)
+	case 2:
+		return fmt.Sprintf("How many nights did %s spend in %s?", f.second, f.town),
+			passageNumber, days(f.secondArrives, f.secondLeaves)
+	case 3:
+		first := f.first
+		if f.secondLeaves.Before(f.firstLeaves) {
+			first = f.second
+		}
+		return fmt.Sprintf("Who left %s first, %s or %s?", f.town, f.first, f.second),
+			passageName, first
+	default:
+		return fmt.Sprintf("How many %s did %s have at the end?", f.item, f.first),
+			passageNumber, strconv.Itoa(f.bought - f.given + f.boughtMore)
+	}
+}
+
+// Generate creates a new reading comprehension challenge
+func (p *PassagePuzzle) Generate() (instructions string, state any) {
+	f := randomPassageFacts()
+	question, kind, answer := f.question()
+
+	instructions = fmt.Sprintf(`Read this passage and answer the question:
+
+%s
+
+Question: %s`, f.passageText(), question)
+
+	return instructions, PassageState{Kind: kind, Answer: answer}
+}
+
+// Validate checks the answer, accepting number words and several date formats
+func (p *PassagePuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(PassageState)
+	if !ok {
+		return false
+	}
+	switch s.Kind {
+	case passageNumber:
+		n, ok := parseIntAnswer(answer)
+		return ok && strconv.Itoa(n) == s.Answer
+	case passageDate:
+		month, day, ok := parseDayMonth(answer)
+		if !ok {
+			return false
+		}
+		wantMonth, wantDay, _ := parseDayMonth(s.Answer)
+		return month == wantMonth && day == wantDay
+	default:
+		return strings.EqualFold(strings.Trim(strings.TrimSpace(answer), ".!"), s.Answer)
+	}
+}
+
+func formatDayMonth(t time.Time) string {
+	return fmt.Sprintf("%d %s", t.Day(), t.Month())
+}
+
+func dayCount(d time.Duration) string {
+	n := int(d.Hours() / 24)
+	if n == 1 {
+		return "one day"
+	}
+	return numberWords[n] + " days"
+}
+
+func daysLater(d time.Duration) string {
+	if d.Hours() == 24 {
+		return "a day later"
+	}
+	return dayCount(d) + " later"
+}
+
+// parseDayMonth reads dates such as "4 June", "June 4th", "the 4th of Jun" or "2023-06-04"
+func parseDayMonth(s string) (time.Month, int, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t.Month(), t.Day(), true
+	}
+
+	var month time.Month
+	day := 0
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '.' || r == '/' }) {
+		if m, ok := monthByPrefix(w); ok {
+			month = m
+			continue
+		}
+		w = strings.TrimRight(w, "stndrh")
+		if n, err := strconv.Atoi(w); err == nil && n >= 1 && n <= 31 {
+			day = n
+		}
+	}
+	return month, day, month != 0 && day != 0
+}
+
+// monthByPrefix matches full month names and three-letter abbreviations
+func monthByPrefix(w string) (time.Month, bool) {
+	if len(w) < 3 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if strings.HasPrefix(name, w) {
+			return m, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,84 @@
+package puzzles
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	passageDayMonth  = regexp.MustCompile(`\d+ (?:January|February|March|April|May|June|July|August|September|October|November|December)`)
+	passageJoined    = regexp.MustCompile(`(?i)(a day|\w+ days) later`)
+	passageLeft      = regexp.MustCompile(`(?i)(\w+) days? after the (?:festival began|start of the festival)`)
+	passageCount     = regexp.MustCompile(`\b\d+\b`)
+	passageWhoFirst  = regexp.MustCompile(`^Who left \w+ first, (\w+) or (\w+)\?$`)
+	passageLeaveDate = regexp.MustCompile(`^On what date did \w+ leave \w+\?$`)
+)
+
+// solvePassage rebuilds the dates and counts of the passage and answers the question
+func solvePassage(instructions string) (string, bool) {
+	text, question, ok := strings.Cut(instructions, "\n\nQuestion: ")
+	if !ok {
+		return "", false
+	}
+
+	// The passage names, in order, the first arrival, the start of the
+	// festival and the day the second visitor left
+	dates := passageDayMonth.FindAllString(text, -1)
+	joined := passageJoined.FindStringSubmatch(text)
+	left := passageLeft.FindStringSubmatch(text)
+	counts := passageCount.FindAllString(passageDayMonth.ReplaceAllString(text, ""), -1)
+	if len(dates) != 3 || joined == nil || left == nil || len(counts) != 3 {
+		return "", false
+	}
+
+	var days [3]time.Time
+	for i, d := range dates {
+		month, day, _ := parseDayMonth(d)
+		days[i] = time.Date(2023, month, day, 0, 0, 0, 0, time.UTC)
+		// Dates only move forward, so a smaller date falls in the next year
+		if i > 0 && days[i].Before(days[i-1]) {
+			days[i] = days[i].AddDate(1, 0, 0)
+		}
+	}
+	firstArrives, festivalStart, secondLeaves := days[0], days[1], days[2]
+
+	joinedAfter := 1
+	if !strings.EqualFold(joined[1], "a day") {
+		joinedAfter, ok = parseIntAnswer(strings.TrimSuffix(joined[1], " days"))
+	}
+	leftAfter, ok2 := parseIntAnswer(left[1])
+	if !ok || !ok2 {
+		return "", false
+	}
+	secondArrives := firstArrives.AddDate(0, 0, joinedAfter)
+	firstLeaves := festivalStart.AddDate(0, 0, leftAfter)
+
+	daysFrom := func(from, to time.Time) string {
+		return strconv.Itoa(int(to.Sub(from).Hours() / 24))
+	}
+	switch {
+	case passageLeaveDate.MatchString(question):
+		return formatDayMonth(firstLeaves), true
+	case strings.HasPrefix(question, "How many days after the"):
+		return daysFrom(festivalStart, secondLeaves), true
+	case strings.HasPrefix(question, "How many nights"):
+		return daysFrom(secondArrives, secondLeaves), true
+	case strings.HasPrefix(question, "Who left"):
+		m := passageWhoFirst.FindStringSubmatch(question)
+		if m == nil {
+			return "", false
+		}
+		if secondLeaves.Before(firstLeaves) {
+			return m[2], true
+		}
+		return m[1], true
+	default:
+		var n [3]int
+		for i, c := range counts {
+			n[i], _ = strconv.Atoi(c)
+		}
+		return strconv.Itoa(n[0] - n[1] + n[2]), true
+	}
+}
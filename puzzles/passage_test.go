@@ -0,0 +1,28 @@
+package puzzles
+
+import "testing"
+
+func TestPassageAnswerFormats(t *testing.T) {
+	p := NewPassagePuzzle()
+	tests := []struct {
+		state  PassageState
+		answer string
+		want   bool
+	}{
+		{PassageState{passageNumber, "12"}, "12", true},
+		{PassageState{passageNumber, "12"}, "twelve", true},
+		{PassageState{passageNumber, "12"}, "13", false},
+		{PassageState{passageDate, "May 4"}, "May 4", true},
+		{PassageState{passageDate, "May 4"}, "4th of May", true},
+		{PassageState{passageDate, "May 4"}, "4 May", true},
+		{PassageState{passageDate, "May 4"}, "May 5", false},
+		{PassageState{passageDate, "May 4"}, "June 4", false},
+		{PassageState{passageName, "Oslo"}, "oslo.", true},
+		{PassageState{passageName, "Oslo"}, "Bergen", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(tt.state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%+v, %q) = %t, want %t", tt.state, tt.answer, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package puzzletest
+
+import (
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"botcha/challenge"
+	"botcha/puzzles"
+	"botcha/puzzles/internal/reference"
+)
+
+// DumbSolver is a deliberately simple solver that uses only public data, such
+// as the word list and clue table, and never reads the puzzle the way an LLM
+// does. A high success rate means a puzzle can be beaten by a script.
+type DumbSolver struct {
+	Name string
+	// Guess returns up to attempts candidate answers, best first
+	Guess func(instructions string, attempts int) []string
+}
+
+// MaxGuessRate is the largest share of challenges a dumb solver may beat
+// within the attempts allowed
+const MaxGuessRate = 0.5
+
+// DumbSolvers returns the solvers run by the audit
+func DumbSolvers() []DumbSolver {
+	return []DumbSolver{
+		{Name: "lookup", Guess: lookupGuess},
+		{Name: "permutations", Guess: permutationGuess},
+		{Name: "options", Guess: optionsGuess},
+		{Name: "dictionary", Guess: dictionaryGuess},
+	}
+}
+
+// AuditResult holds how often each dumb solver beat one puzzle
+type AuditResult struct {
+	Puzzle    string
+	Generated int
+	// Solved counts challenges solved by each solver, keyed by solver name
+	Solved map[string]int
+}
+
+// Rate returns the share of challenges a solver beat, from 0 to 1
+func (r AuditResult) Rate(solver string) float64 {
+	if r.Generated == 0 {
+		return 0
+	}
+	return float64(r.Solved[solver]) / float64(r.Generated)
+}
+
+// OverGuessRate lists the solvers that beat more than MaxGuessRate of the
+// audited challenges of c. The lookup solver reads every clue of a Scriptable
+// case and is left out for it.
+func (c Case) OverGuessRate(r AuditResult) []string {
+	var over []string
+	for _, s := range DumbSolvers() {
+		if c.Scriptable && s.Name == "lookup" {
+			continue
+		}
+		if r.Rate(s.Name) > MaxGuessRate {
+			over = append(over, s.Name)
+		}
+	}
+	return over
+}
+
+// Audit generates n challenges and counts those each solver beats within the
+// given number of attempts, as allowed by the middleware's SetMaxAttempts
+func Audit(p challenge.Puzzle, n, attempts int, solvers []DumbSolver) AuditResult {
+	r := AuditResult{Puzzle: p.Name(), Solved: make(map[string]int)}
+	for range n {
+		instructions, state := p.Generate()
+		r.Generated++
+		for _, s := range solvers {
+			for _, guess := range s.Guess(instructions, attempts) {
+				if p.Validate(state, guess) {
+					r.Solved[s.Name]++
+					break
+				}
+			}
+		}
+	}
+	return r
+}
+
+// lookupGuess reads the "Scrambled"/"Sequence" lines of a challenge the way
+// the built-in puzzles write numbers: digits, shortened number words and
+// clues from puzzles.NumberQuestions, alone or added and subtracted. It reads
+// the letters at those positions and guesses words that match the pattern
+// and can be spelled from the scrambled letters.
+func lookupGuess(instructions string, attempts int) []string {
+	scrambled, ok := instructionLine(instructions, "Scrambled: ")
+	list, ok2 := instructionLine(instructions, "Sequence: ")
+	if !ok || !ok2 {
+		return nil
+	}
+	items := strings.Split(strings.Trim(list, "[]"), ", ")
+
+	pattern := make([]byte, len(items))
+	for i, item := range items {
+		pattern[i] = '?'
+		if n, ok := reference.ReadNumber(item); ok && n >= 1 && n <= len(scrambled) {
+			pattern[i] = scrambled[n-1]
+		}
+	}
+
+	var candidates []string
+	for _, w := range puzzles.ChallengeWords {
+		if matchesPattern(w, pattern) && containsLetters(scrambled, w) {
+			candidates = append(candidates, w)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	return candidates[:min(attempts, len(candidates))]
+}
+
+// matchesPattern reports whether word fits a pattern in which '?' matches any letter
+func matchesPattern(word string, pattern []byte) bool {
+	if len(word) != len(pattern) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != '?' && pattern[i] != word[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// optionLine matches labelled options such as "B. The kettle boiled." or "3. xy12"
+var optionLine = regexp.MustCompile(`(?m)^([A-Z]|\d+)\. `)
+
+// permutationGuess tries random orderings of lettered options and random
+// subsets of numbered ones, without reading them
+func permutationGuess(instructions string, attempts int) []string {
+	var letters, numbers []string
+	for _, m := range optionLine.FindAllStringSubmatch(instructions, -1) {
+		if _, err := strconv.Atoi(m[1]); err == nil {
+			numbers = append(numbers, m[1])
+		} else {
+			letters = append(letters, m[1])
+		}
+	}
+
+	seen := make(map[string]bool)
+	var guesses []string
+	// Small option sets have fewer distinct guesses than attempts
+	for try := 0; len(guesses) < attempts && try < attempts*10; try++ {
+		var guess string
+		switch {
+		case len(letters) > 1:
+			perm := slices.Clone(letters)
+			rand.Shuffle(len(perm), func(i, j int) { perm[i], perm[j] = perm[j], perm[i] })
+			guess = strings.Join(perm, "")
+		case len(numbers) > 1:
+			var subset []string
+			for _, n := range numbers {
+				if rand.Intn(2) == 0 {
+					subset = append(subset, n)
+				}
+			}
+			guess = strings.Join(subset, ", ")
+		default:
+			return nil
+		}
+		if guess != "" && !seen[guess] {
+			seen[guess] = true
+			guesses = append(guesses, guess)
+		}
+	}
+	return guesses
+}
+
+// optionList matches a line listing options after a label, such as
+// "Pets: cat, dog, fish"
+var optionList = regexp.MustCompile(`(?m)^(\w+): (\w+(?:, \w+)+)$`)
+
+// tableCells splits the rows of a table on runs of spaces
+var tableCells = regexp.MustCompile(`\s{2,}`)
+
+// optionSet is a list of options and the label it was given
+type optionSet struct {
+	title  string
+	values []string
+}
+
+// optionsGuess reads the option lists of a challenge: comma-separated lists
+// after a label, and the row names and column headings of a table. It guesses
+// options from the lists the question names, or from all lists if it names
+// none, skipping options the question mentions. A question that names two
+// lists is answered with a pair.
+func optionsGuess(instructions string, attempts int) []string {
+	var sets []optionSet
+	for _, m := range optionList.FindAllStringSubmatch(instructions, -1) {
+		sets = append(sets, optionSet{m[1], strings.Split(m[2], ", ")})
+	}
+	sets = append(sets, tableOptions(instructions)...)
+
+	question := ""
+	for _, line := range strings.Split(instructions, "\n") {
+		if strings.Contains(line, "?") {
+			question = line
+		}
+	}
+	words := strings.FieldsFunc(strings.ToLower(question), func(r rune) bool { return !unicode.IsLetter(r) })
+	asks := func(title string) bool {
+		title = strings.TrimSuffix(strings.ToLower(title), "s")
+		return title != "" && slices.Contains(words, title) || title == "name" && slices.Contains(words, "who")
+	}
+
+	var named, all [][]string
+	for _, set := range sets {
+		var values []string
+		for _, v := range set.values {
+			if !slices.Contains(words, strings.ToLower(v)) {
+				values = append(values, v)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		all = append(all, values)
+		if asks(set.title) {
+			named = append(named, values)
+		}
+	}
+	if len(named) == 0 {
+		named = all
+	}
+
+	var candidates []string
+	if len(named) == 2 {
+		for _, a := range named[0] {
+			for _, b := range named[1] {
+				candidates = append(candidates, a+", "+b)
+			}
+		}
+	} else {
+		for _, values := range named {
+			candidates = append(candidates, values...)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	return candidates[:min(attempts, len(candidates))]
+}
+
+// tableOptions reads the row names of a table, labelled by the heading of the
+// first column, and its other column headings
+func tableOptions(instructions string) []optionSet {
+	var header, rows []string
+	for _, line := range strings.Split(instructions, "\n") {
+		cells := tableCells.Split(strings.TrimSpace(line), -1)
+		switch {
+		case len(cells) < 3:
+			if rows != nil {
+				return []optionSet{{header[0], rows}, {"", header[1:]}}
+			}
+			header = nil
+		case header == nil:
+			header = cells
+		default:
+			rows = append(rows, cells[0])
+		}
+	}
+	if rows != nil {
+		return []optionSet{{header[0], rows}, {"", header[1:]}}
+	}
+	return nil
+}
+
+// dictionaryGuess matches the longest run of letters in the instructions, such
+// as a scrambled or encoded word, against ChallengeWords. Words whose letters
+// all occur in the run come first, fewest leftover letters first, followed by
+// words of the same length.
+func dictionaryGuess(instructions string, attempts int) []string {
+	run := ""
+	for _, field := range strings.Fields(instructions) {
+		if len(field) > len(run) && isLowerWord(field) {
+			run = field
+		}
+	}
+	if len(run) < 6 {
+		return nil
+	}
+
+	words := slices.Clone(puzzles.ChallengeWords)
+	rand.Shuffle(len(words), func(i, j int) { words[i], words[j] = words[j], words[i] })
+
+	var contained, sameLength []string
+	for _, w := range words {
+		switch {
+		case containsLetters(run, w):
+			contained = append(contained, w)
+		case len(w) == len(run):
+			sameLength = append(sameLength, w)
+		}
+	}
+	slices.SortStableFunc(contained, func(a, b string) int { return len(b) - len(a) })
+
+	candidates := append(contained, sameLength...)
+	return candidates[:min(attempts, len(candidates))]
+}
+
+func isLowerWord(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// containsLetters reports whether every letter of word, with repeats, occurs in s
+func containsLetters(s, word string) bool {
+	var counts [26]int
+	for _, r := range s {
+		counts[r-'a']++
+	}
+	for _, r := range word {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+		counts[r-'a']--
+		if counts[r-'a'] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// instructionLine returns the rest of the first line that starts with prefix
+func instructionLine(instructions, prefix string) (string, bool) {
+	for _, line := range strings.Split(instructions, "\n") {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,48 @@
+package puzzletest
+
+import "testing"
+
+// auditAttempts matches the SetMaxAttempts of the demo server
+const auditAttempts = 3
+
+func TestDumbSolversStayBelowGuessRate(t *testing.T) {
+	for _, c := range BuiltinCases() {
+		r := Audit(c.Puzzle, 300, auditAttempts, DumbSolvers())
+		for _, s := range c.OverGuessRate(r) {
+			t.Errorf("%s: %s solver beat %.0f%% of challenges, want at most %.0f%%",
+				r.Puzzle, s, 100*r.Rate(s), 100*MaxGuessRate)
+		}
+		if c.Scriptable && r.Rate("lookup") < 0.9 {
+			t.Errorf("%s: lookup solver beat only %.0f%% of challenges of a scriptable puzzle", r.Puzzle, 100*r.Rate("lookup"))
+		}
+	}
+}
+
+func TestOptionsGuess(t *testing.T) {
+	table := `Sales by region and month
+
+Region    Feb   Mar   Apr
+South      98    32    14
+East       11    50    84
+
+Question: Which region sold the most in Mar?`
+	got := optionsGuess(table, 5)
+	if len(got) != 2 || !(got[0] == "South" || got[0] == "East") {
+		t.Errorf("table: got %q, want the two regions", got)
+	}
+
+	grid := `Names: Ann, Bob
+Pets: cat, dog
+Drinks: tea, milk
+
+Question: What are the name and the pet of the tea drinker?`
+	got = optionsGuess(grid, 10)
+	if len(got) != 4 {
+		t.Errorf("grid: got %q, want the four name and pet pairs", got)
+	}
+	for _, g := range got {
+		if g != "Ann, cat" && g != "Ann, dog" && g != "Bob, cat" && g != "Bob, dog" {
+			t.Errorf("grid: unexpected guess %q", g)
+		}
+	}
+}
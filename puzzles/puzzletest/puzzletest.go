@@ -0,0 +1,153 @@
+// Package puzzletest bulk-generates challenges and checks that each one is well
+// formed, can be solved by a reference solver and does not leak its answer.
+package puzzletest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"botcha/challenge"
+	"botcha/puzzles"
+	"botcha/puzzles/internal/reference"
+)
+
+// maxReportedFailures caps the failures kept per puzzle
+const maxReportedFailures = 5
+
+// Case is a puzzle to check
+type Case struct {
+	Puzzle challenge.Puzzle
+	// AnswerShown is set for puzzles whose answer is one of the options listed
+	// in the instructions, such as a name in a logic grid
+	AnswerShown bool
+	// Scriptable is set for puzzles that a script holding the public word
+	// list and clue table solves outright, such as scramble and charade
+	Scriptable bool
+	// Solve, if set, is a reference solver that rebuilds the answer from the
+	// instructions alone
+	Solve func(instructions string) (answer string, ok bool)
+}
+
+// BuiltinCases returns a case for every built-in puzzle, each with its
+// reference solver
+func BuiltinCases() []Case {
+	cases := []Case{
+		{Puzzle: puzzles.NewScramblePuzzle(), Scriptable: true},
+		{Puzzle: puzzles.NewCharadePuzzle(), Scriptable: true},
+		{Puzzle: puzzles.NewMathStoryPuzzle()},
+		{Puzzle: puzzles.NewCodeTracePuzzle()},
+		{Puzzle: puzzles.NewLogicGridPuzzle(), AnswerShown: true},
+		{Puzzle: puzzles.NewCipherPuzzle()},
+		{Puzzle: puzzles.NewJSONTransformPuzzle(), AnswerShown: true},
+		{Puzzle: puzzles.NewPassagePuzzle(), AnswerShown: true},
+		{Puzzle: puzzles.NewSequencePuzzle()},
+		{Puzzle: puzzles.NewASCIIArtPuzzle()},
+		{Puzzle: puzzles.NewConversionPuzzle()},
+		{Puzzle: puzzles.NewDatePuzzle()},
+		{Puzzle: puzzles.NewRegexPuzzle(), AnswerShown: true},
+		{Puzzle: puzzles.NewTablePuzzle(), AnswerShown: true},
+		{Puzzle: puzzles.NewStoryOrderPuzzle(), AnswerShown: true},
+		{Puzzle: puzzles.NewRebusPuzzle()},
+	}
+	for i := range cases {
+		cases[i].Solve = reference.Solvers[cases[i].Puzzle.Name()]
+	}
+	return cases
+}
+
+// Result summarizes the checks of one puzzle
+type Result struct {
+	Puzzle    string
+	Generated int
+	// Solved counts reference answers accepted by Validate; it stays 0 for
+	// cases without a reference solver
+	Solved    int
+	HasSolver bool
+	Failures  int
+	// Samples describes the first few failures
+	Samples []string
+}
+
+// OK reports whether every generated challenge passed
+func (r Result) OK() bool {
+	return r.Failures == 0
+}
+
+// unresolvedFallback matches text that should never reach a challenge: the
+// clue fallback for numbers without questions and fmt formatting errors
+var unresolvedFallback = regexp.MustCompile(`the number \d+|%!|\(MISSING\)|<nil>`)
+
+// Check generates n challenges and verifies each one:
+//   - the instructions contain no unresolved fallbacks
+//   - an empty answer is rejected
+//   - the reference answer, if the case has a solver, passes Validate
+//   - the answer does not appear verbatim in the instructions, unless c.AnswerShown
+func Check(c Case, n int) Result {
+	p := c.Puzzle
+	answerer, hasAnswerer := p.(challenge.Answerer)
+	r := Result{Puzzle: p.Name(), HasSolver: c.Solve != nil}
+
+	fail := func(instructions, format string, args ...any) {
+		r.Failures++
+		if len(r.Samples) < maxReportedFailures {
+			r.Samples = append(r.Samples, fmt.Sprintf(format, args...)+"\n"+indent(instructions))
+		}
+	}
+
+	for range n {
+		instructions, state := p.Generate()
+		r.Generated++
+
+		if m := unresolvedFallback.FindString(instructions); m != "" {
+			fail(instructions, "unresolved fallback %q", m)
+			continue
+		}
+		if p.Validate(state, "") {
+			fail(instructions, "empty answer accepted")
+			continue
+		}
+
+		answer := ""
+		if c.Solve != nil {
+			var ok bool
+			answer, ok = c.Solve(instructions)
+			if !ok {
+				fail(instructions, "reference solver found no answer")
+				continue
+			}
+			if !p.Validate(state, answer) {
+				fail(instructions, "reference answer %q rejected", answer)
+				continue
+			}
+			r.Solved++
+		} else if hasAnswerer {
+			answer = answerer.Answer(state)
+		}
+
+		if !c.AnswerShown && leaksAnswer(instructions, answer) {
+			fail(instructions, "answer %q appears in the instructions", answer)
+		}
+	}
+	return r
+}
+
+// leaksAnswer reports whether a word-like answer appears in the instructions.
+// Short and numeric answers are skipped since they occur naturally.
+func leaksAnswer(instructions, answer string) bool {
+	letters := 0
+	for _, r := range answer {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < 4 {
+		return false
+	}
+	return strings.Contains(strings.ToLower(instructions), strings.ToLower(answer))
+}
+
+func indent(text string) string {
+	return "    " + strings.ReplaceAll(text, "\n", "\n    ")
+}
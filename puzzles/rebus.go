@@ -0,0 +1,354 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// RebusState holds the puzzle state stored in the session
+type RebusState struct {
+	Word string
+}
+
+// RebusPuzzle implements the emoji rebus challenge
+type RebusPuzzle struct{}
+
+// NewRebusPuzzle creates a new rebus puzzle instance
+func NewRebusPuzzle() *RebusPuzzle {
+	return &RebusPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *RebusPuzzle) Name() string {
+	return "rebus"
+}
+
+// rebusPictures maps each picture word to the emoji that can stand for it
+var rebusPictures = map[string][]string{
+	"ant":    {"🐜"},
+	"apple":  {"🍎"},
+	"bag":    {"👜"},
+	"ball":   {"⚽"},
+	"bat":    {"🦇"},
+	"bear":   {"🐻"},
+	"bee":    {"🐝"},
+	"bell":   {"🔔"},
+	"boat":   {"⛵"},
+	"book":   {"📚", "📖"},
+	"bow":    {"🎀"},
+	"bowl":   {"🥣"},
+	"box":    {"📦"},
+	"boy":    {"👦"},
+	"bug":    {"🐛"},
+	"bull":   {"🐂"},
+	"butter": {"🧈"},
+	"cake":   {"🎂"},
+	"can":    {"🥫"},
+	"car":    {"🚗", "🚙"},
+	"cat":    {"🐈", "🐱"},
+	"corn":   {"🌽"},
+	"cow":    {"🐄", "🐮"},
+	"dog":    {"🐕", "🐶"},
+	"door":   {"🚪"},
+	"dragon": {"🐉"},
+	"ear":    {"👂"},
+	"egg":    {"🥚"},
+	"eye":    {"👁️"},
+	"fire":   {"🔥"},
+	"fish":   {"🐟", "🐠"},
+	"flower": {"🌼", "🌸"},
+	"fly":    {"🪰"},
+	"foot":   {"🦶"},
+	"girl":   {"👧"},
+	"goat":   {"🐐"},
+	"hand":   {"✋"},
+	"hat":    {"🎩"},
+	"honey":  {"🍯"},
+	"hook":   {"🪝"},
+	"horn":   {"📯"},
+	"horse":  {"🐎"},
+	"house":  {"🏠"},
+	"key":    {"🔑"},
+	"king":   {"🤴"},
+	"leg":    {"🦵"},
+	"light":  {"💡"},
+	"log":    {"🪵"},
+	"man":    {"👨"},
+	"money":  {"💰"},
+	"moon":   {"🌙"},
+	"night":  {"🌃"},
+	"owl":    {"🦉"},
+	"pan":    {"🍳"},
+	"pear":   {"🍐"},
+	"pen":    {"🖊️"},
+	"pig":    {"🐖"},
+	"pin":    {"📌"},
+	"pine":   {"🌲"},
+	"plane":  {"✈️"},
+	"plant":  {"🪴"},
+	"rain":   {"🌧️"},
+	"rat":    {"🐀"},
+	"ring":   {"💍"},
+	"sea":    {"🌊"},
+	"shell":  {"🐚"},
+	"shoe":   {"👞"},
+	"snow":   {"❄️"},
+	"star":   {"⭐", "🌟"},
+	"sun":    {"☀️", "🌞"},
+	"tie":    {"👔"},
+	"tree":   {"🌳"},
+	"van":    {"🚐"},
+	"wind":   {"🌬️"},
+	"wing":   {"🪽"},
+	"worm":   {"🪱"},
+}
+
+// rebusEntry is a curated answer with one or more ways to compose it. Each
+// composition is a list of steps: a picture word, "+x" to add letters, "-x" to
+// remove the last occurrence of letters, or "x>y" to replace the last x with y.
+type rebusEntry struct {
+	word         string
+	compositions [][]string
+}
+
+var rebusDictionary = []rebusEntry{
+	{"sunflower", [][]string{{"sun", "flower"}, {"sun", "fly", "y>o", "+wer"}}},
+	{"starfish", [][]string{{"star", "fish"}, {"star", "fire", "-re", "+sh"}}},
+	{"rainbow", [][]string{{"rain", "bow"}}},
+	{"doorbell", [][]string{{"door", "bell"}}},
+	{"butterfly", [][]string{{"butter", "fly"}, {"butter", "flower", "-ower", "+y"}}},
+	{"earring", [][]string{{"ear", "ring"}}},
+	{"honeybee", [][]string{{"honey", "bee"}}},
+	{"moonlight", [][]string{{"moon", "light"}}},
+	{"snowman", [][]string{{"snow", "man"}}},
+	{"cowboy", [][]string{{"cow", "boy"}}},
+	{"catfish", [][]string{{"cat", "fish"}}},
+	{"bookworm", [][]string{{"book", "worm"}}},
+	{"firefly", [][]string{{"fire", "fly"}, {"fire", "flower", "-ower", "+y"}}},
+	{"pineapple", [][]string{{"pine", "apple"}}},
+	{"handbag", [][]string{{"hand", "bag"}}},
+	{"bulldog", [][]string{{"bull", "dog"}}},
+	{"horseshoe", [][]string{{"horse", "shoe"}}},
+	{"beard", [][]string{{"bee", "-e", "ear", "-e", "+d"}, {"bee", "-e", "+ard"}}},
+	{"window", [][]string{{"wind", "owl", "-l"}}},
+	{"carrot", [][]string{{"car", "rat", "a>o"}}},
+	{"brain", [][]string{{"+b", "rain"}}},
+	{"pearl", [][]string{{"pear", "+l"}}},
+	{"heart", [][]string{{"+h", "ear", "+t"}}},
+	{"planet", [][]string{{"plane", "+t"}, {"plant", "t>e", "+t"}}},
+	{"scar", [][]string{{"+s", "car"}}},
+	{"corner", [][]string{{"corn", "+er"}}},
+	{"stare", [][]string{{"star", "+e"}}},
+	{"catalog", [][]string{{"cat", "+a", "log"}}},
+	{"bellow", [][]string{{"bell", "owl", "-l"}, {"bell", "+ow"}}},
+	{"cowbell", [][]string{{"cow", "bell"}}},
+	{"pirate", [][]string{{"pine", "-ne", "rat", "+e"}}},
+	{"manor", [][]string{{"man", "+or"}}},
+	{"flyer", [][]string{{"fly", "+er"}}},
+	{"eyeball", [][]string{{"eye", "ball"}}},
+	{"football", [][]string{{"foot", "ball"}}},
+	{"snowball", [][]string{{"snow", "ball"}}},
+	{"pinball", [][]string{{"pin", "ball"}}},
+	{"pancake", [][]string{{"pan", "cake"}}},
+	{"seahorse", [][]string{{"sea", "horse"}}},
+	{"seashell", [][]string{{"sea", "shell"}, {"sea", "+sh", "bell", "-b"}}},
+	{"season", [][]string{{"sea", "+son"}}},
+	{"houseboat", [][]string{{"house", "boat"}}},
+	{"eggplant", [][]string{{"egg", "plant"}}},
+	{"eggshell", [][]string{{"egg", "shell"}, {"egg", "+sh", "bell", "-b"}}},
+	{"shellfish", [][]string{{"shell", "fish"}, {"+sh", "bell", "-b", "fish"}}},
+	{"boxcar", [][]string{{"box", "car"}}},
+	{"treehouse", [][]string{{"tree", "house"}}},
+	{"doghouse", [][]string{{"dog", "house"}}},
+	{"lighthouse", [][]string{{"light", "house"}}},
+	{"housefly", [][]string{{"house", "fly"}}},
+	{"horsefly", [][]string{{"horse", "fly"}}},
+	{"dragonfly", [][]string{{"dragon", "fly"}, {"dragon", "flower", "-ower", "+y"}}},
+	{"moneybag", [][]string{{"money", "bag"}}},
+	{"honeymoon", [][]string{{"honey", "moon"}}},
+	{"starlight", [][]string{{"star", "light"}}},
+	{"hatbox", [][]string{{"hat", "box"}}},
+	{"keyring", [][]string{{"key", "ring"}}},
+	{"wingman", [][]string{{"wing", "man"}}},
+	{"fishbowl", [][]string{{"fish", "bowl"}}},
+	{"bowtie", [][]string{{"bow", "tie"}}},
+	{"cowgirl", [][]string{{"cow", "girl"}}},
+	{"hookworm", [][]string{{"hook", "worm"}}},
+	{"kingfisher", [][]string{{"king", "fish", "+er"}}},
+	{"wingspan", [][]string{{"wing", "+s", "pan"}}},
+	{"bearing", [][]string{{"bear", "+ing"}, {"bear", "ring", "-r"}}},
+	{"planter", [][]string{{"plant", "+er"}}},
+	{"button", [][]string{{"butter", "-er", "+on"}}},
+	{"carpet", [][]string{{"car", "+pet"}}},
+	{"penguin", [][]string{{"pen", "+guin"}}},
+	{"pigeon", [][]string{{"pig", "+eon"}}},
+	{"hatchet", [][]string{{"hat", "+chet"}}},
+	{"earthworm", [][]string{{"ear", "+th", "worm"}}},
+	{"goatee", [][]string{{"goat", "+ee"}}},
+	{"bowling", [][]string{{"bowl", "+ing"}}},
+	{"kingdom", [][]string{{"king", "+dom"}}},
+	{"tiger", [][]string{{"tie", "-e", "+ger"}}},
+	{"ballet", [][]string{{"ball", "+et"}}},
+	{"candle", [][]string{{"can", "+dle"}}},
+	{"handle", [][]string{{"hand", "+le"}}},
+	{"panda", [][]string{{"pan", "+da"}}},
+	{"hornet", [][]string{{"horn", "+et"}}},
+	{"beetle", [][]string{{"bee", "+tle"}}},
+	{"owlet", [][]string{{"owl", "+et"}}},
+	{"bullet", [][]string{{"bull", "+et"}}},
+	{"booklet", [][]string{{"book", "+let"}}},
+	{"spear", [][]string{{"+s", "pear"}}},
+	{"antler", [][]string{{"ant", "+ler"}}},
+	{"vanish", [][]string{{"van", "+ish"}}},
+	{"bugle", [][]string{{"bug", "+le"}}},
+	{"batch", [][]string{{"bat", "+ch"}}},
+}
+
+// Generate creates a new rebus challenge
+func (p *RebusPuzzle) Generate() (instructions string, state any) {
+	for {
+		entry := rebusDictionary[rand.Intn(len(rebusDictionary))]
+		steps := disguiseRebus(entry.word, entry.compositions[rand.Intn(len(entry.compositions))])
+
+		// Compositions of pictures alone can be read off with an emoji table
+		if !slices.ContainsFunc(steps, isLetterStep) {
+			continue
+		}
+
+		instructions = fmt.Sprintf(`Solve this rebus. Each picture stands for the word it shows; letter steps are applied in order.
+
+%s
+
+"+ x" adds letters, "− x" removes the last occurrence of those letters, and "x → y" replaces the last x with y.
+Answer with the resulting word.`, renderRebus(steps))
+
+		return instructions, RebusState{Word: entry.word}
+	}
+}
+
+// Validate checks if the answer matches the expected word, ignoring case
+func (p *RebusPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(RebusState)
+	if !ok {
+		return false
+	}
+	return matchesWord(answer, s.Word)
+}
+
+// Answer returns the expected word, so the puzzle can key another puzzle
+func (p *RebusPuzzle) Answer(state any) string {
+	s, _ := state.(RebusState)
+	return s.Word
+}
+
+// disguiseRebus swaps pictures for neighbouring ones, such as a rat for a cat,
+// and adds the letter step that turns them back: "🐀 (r → c)". Each picture is
+// swapped with even odds when a neighbour fits.
+func disguiseRebus(word string, steps []string) []string {
+	steps = slices.Clone(steps)
+	for i := 0; i < len(steps); i++ {
+		if isLetterStep(steps[i]) || rand.Intn(2) == 0 {
+			continue
+		}
+		var options [][]string
+		for neighbour := range rebusPictures {
+			if fix, ok := pictureFix(neighbour, steps[i]); ok {
+				candidate := slices.Concat(steps[:i], []string{neighbour, fix}, steps[i+1:])
+				// Letter steps act on the last occurrence, so check the swap in place
+				if composeRebus(candidate) == word {
+					options = append(options, candidate)
+				}
+			}
+		}
+		if len(options) > 0 {
+			steps = options[rand.Intn(len(options))]
+			i++
+		}
+	}
+	return steps
+}
+
+// pictureFix returns the letter step that turns picture word from into to,
+// if they differ by one replaced letter, one extra letter or one missing final letter
+func pictureFix(from, to string) (string, bool) {
+	switch {
+	case len(from) == len(to):
+		diff := -1
+		for i := range from {
+			if from[i] != to[i] {
+				if diff >= 0 {
+					return "", false
+				}
+				diff = i
+			}
+		}
+		if diff < 0 {
+			return "", false
+		}
+		return from[diff:diff+1] + ">" + to[diff:diff+1], true
+	case len(from) == len(to)+1:
+		for i := range from {
+			if from[:i]+from[i+1:] == to {
+				return "-" + from[i:i+1], true
+			}
+		}
+	case len(from)+1 == len(to) && strings.HasPrefix(to, from):
+		return "+" + to[len(from):], true
+	}
+	return "", false
+}
+
+// isLetterStep reports whether a composition step is a letter operation
+// rather than a picture
+func isLetterStep(step string) bool {
+	return strings.HasPrefix(step, "+") || strings.HasPrefix(step, "-") || strings.Contains(step, ">")
+}
+
+// renderRebus shows the steps with a randomly chosen emoji for each picture
+func renderRebus(steps []string) string {
+	parts := make([]string, 0, len(steps))
+	for i, step := range steps {
+		switch {
+		case strings.HasPrefix(step, "+"):
+			parts = append(parts, "+ "+step[1:])
+		case strings.HasPrefix(step, "-"):
+			parts = append(parts, "− "+step[1:])
+		case strings.Contains(step, ">"):
+			from, to, _ := strings.Cut(step, ">")
+			parts = append(parts, fmt.Sprintf("(%s → %s)", from, to))
+		default:
+			emoji := rebusPictures[step]
+			picture := emoji[rand.Intn(len(emoji))]
+			if i > 0 {
+				picture = "+ " + picture
+			}
+			parts = append(parts, picture)
+		}
+	}
+	return strings.TrimPrefix(strings.Join(parts, " "), "+ ")
+}
+
+// composeRebus applies the steps of a composition and returns the resulting word
+func composeRebus(steps []string) string {
+	word := ""
+	for _, step := range steps {
+		switch {
+		case strings.HasPrefix(step, "+"):
+			word += step[1:]
+		case strings.HasPrefix(step, "-"):
+			if i := strings.LastIndex(word, step[1:]); i >= 0 {
+				word = word[:i] + word[i+len(step)-1:]
+			}
+		case strings.Contains(step, ">"):
+			from, to, _ := strings.Cut(step, ">")
+			if i := strings.LastIndex(word, from); i >= 0 {
+				word = word[:i] + to + word[i+len(from):]
+			}
+		default:
+			word += step
+		}
+	}
+	return word
+}
@@ -0,0 +1,42 @@
+package puzzles
+
+import "strings"
+
+// solveRebus is the reference solver: it reads each emoji back as its picture word
+// and applies the letter steps
+func solveRebus(instructions string) (string, bool) {
+	lines := strings.Split(instructions, "\n")
+	if len(lines) < 3 {
+		return "", false
+	}
+	words := make(map[string]string)
+	for word, emoji := range rebusPictures {
+		for _, e := range emoji {
+			words[e] = word
+		}
+	}
+
+	// Pictures and added letters are both joined with " + "
+	var steps []string
+	for _, part := range strings.Split(lines[2], " + ") {
+		fields := strings.Split(part, " ")
+		if word, ok := words[fields[0]]; ok {
+			steps = append(steps, word)
+		} else {
+			steps = append(steps, "+"+fields[0])
+		}
+		for i := 1; i < len(fields); i++ {
+			switch {
+			case fields[i] == "−" && i+1 < len(fields):
+				steps = append(steps, "-"+fields[i+1])
+				i++
+			case strings.HasPrefix(fields[i], "(") && i+2 < len(fields):
+				steps = append(steps, strings.TrimPrefix(fields[i], "(")+">"+strings.TrimSuffix(fields[i+2], ")"))
+				i += 2
+			default:
+				return "", false
+			}
+		}
+	}
+	return composeRebus(steps), true
+}
@@ -0,0 +1,52 @@
+package puzzles
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRebusCompositions(t *testing.T) {
+	for _, entry := range rebusDictionary {
+		for _, steps := range entry.compositions {
+			if got := composeRebus(steps); got != entry.word {
+				t.Errorf("composition %v yields %q, want %q", steps, got, entry.word)
+			}
+			for _, step := range steps {
+				if _, ok := rebusPictures[step]; !ok && !isLetterStep(step) {
+					t.Errorf("composition %v uses unknown picture %q", steps, step)
+				}
+			}
+		}
+	}
+}
+
+func TestRebusPicturesAreDistinct(t *testing.T) {
+	seen := make(map[string]string)
+	for word, emoji := range rebusPictures {
+		for _, e := range emoji {
+			if other, ok := seen[e]; ok {
+				t.Errorf("emoji %s stands for both %q and %q", e, other, word)
+			}
+			seen[e] = word
+		}
+	}
+}
+
+// Every entry must be drawable with a letter step, or Generate never picks it
+func TestRebusEntriesNeedLetterSteps(t *testing.T) {
+	for _, entry := range rebusDictionary {
+		found := false
+		for range 100 {
+			for _, steps := range entry.compositions {
+				disguised := disguiseRebus(entry.word, steps)
+				if composeRebus(disguised) != entry.word {
+					t.Fatalf("disguised composition %v yields %q, want %q", disguised, composeRebus(disguised), entry.word)
+				}
+				found = found || slices.ContainsFunc(disguised, isLetterStep)
+			}
+		}
+		if !found {
+			t.Errorf("%q is only ever drawn as pictures", entry.word)
+		}
+	}
+}
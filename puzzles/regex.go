@@ -0,0 +1,224 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RegexState holds the puzzle state stored in the session
+type RegexState struct {
+	Matches []int
+}
+
+// RegexPuzzle implements the regular expression matching challenge
+type RegexPuzzle struct{}
+
+// NewRegexPuzzle creates a new regex puzzle instance
+func NewRegexPuzzle() *RegexPuzzle {
+	return &RegexPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *RegexPuzzle) Name() string {
+	return "regex"
+}
+
+// regexAlphabet is the set of characters patterns and candidates are built from
+const regexAlphabet = "abcdexyz0123456789"
+
+// regexAtom is one element of a pattern and a generator of text it matches
+type regexAtom struct {
+	pattern string
+	gen     func() string
+}
+
+// regexAtoms build the atoms of the safe grammar: literals, classes, ranges,
+// the dot and small alternations. No backreferences or nested groups.
+var regexAtoms = []func() regexAtom{
+	func() regexAtom {
+		c := string(regexAlphabet[rand.Intn(len(regexAlphabet))])
+		return regexAtom{c, func() string { return c }}
+	},
+	func() regexAtom {
+		perm := rand.Perm(8)[:between(2, 3)]
+		var set []byte
+		for _, i := range perm {
+			set = append(set, regexAlphabet[i])
+		}
+		return regexAtom{"[" + string(set) + "]", func() string { return string(set[rand.Intn(len(set))]) }}
+	},
+	func() regexAtom {
+		return regexAtom{`\d`, func() string { return strconv.Itoa(rand.Intn(10)) }}
+	},
+	func() regexAtom {
+		lo := between(0, 3)
+		hi := lo + between(1, 3)
+		return regexAtom{fmt.Sprintf("[%c-%c]", 'a'+lo, 'a'+hi), func() string { return string(rune('a' + between(lo, hi))) }}
+	},
+	func() regexAtom {
+		return regexAtom{".", func() string { return string(regexAlphabet[rand.Intn(len(regexAlphabet))]) }}
+	},
+	func() regexAtom {
+		first, second := randomRegexText(2), randomRegexText(between(1, 2))
+		return regexAtom{"(" + first + "|" + second + ")", func() string { return pick(first, second) }}
+	},
+}
+
+// regexQuantifier is a quantifier and the repeat counts it allows
+type regexQuantifier struct {
+	suffix   string
+	min, max int
+}
+
+var regexQuantifiers = []regexQuantifier{
+	{"", 1, 1}, {"", 1, 1}, {"", 1, 1},
+	{"?", 0, 1},
+	{"+", 1, 3},
+	{"*", 0, 3},
+	{"{2}", 2, 2},
+	{"{1,3}", 1, 3},
+}
+
+// Generate creates a new regex matching challenge
+func (p *RegexPuzzle) Generate() (instructions string, state any) {
+	for {
+		pattern, gen := randomRegex()
+		re := regexp.MustCompile(pattern)
+
+		count := between(8, 12)
+		seen := make(map[string]bool)
+		var candidates []string
+		for len(candidates) < count {
+			s := gen()
+			if rand.Intn(2) == 0 {
+				s = mutateRegexText(s)
+			}
+			if s == "" || seen[s] {
+				continue
+			}
+			seen[s] = true
+			candidates = append(candidates, s)
+		}
+
+		var matches []int
+		var lines []string
+		for i, s := range candidates {
+			if re.MatchString(s) {
+				matches = append(matches, i+1)
+			}
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, s))
+		}
+		if len(matches) < 2 || len(matches) > count-2 {
+			continue
+		}
+
+		instructions = fmt.Sprintf(`Which of these strings does the regular expression match in full?
+
+Pattern: %s
+
+%s
+
+Answer with the numbers of all matching strings, for example: 2, 5, 6`, pattern, strings.Join(lines, "\n"))
+
+		return instructions, RegexState{Matches: matches}
+	}
+}
+
+// Validate checks if the answer lists exactly the matching indices, in any order
+func (p *RegexPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(RegexState)
+	if !ok {
+		return false
+	}
+	got, ok := parseIndexSet(answer)
+	return ok && slices.Equal(got, s.Matches)
+}
+
+// randomRegex builds an anchored pattern of 3-5 quantified atoms and a generator
+// of strings it matches
+func randomRegex() (string, func() string) {
+	var parts []string
+	var gens []func() string
+	for range between(3, 5) {
+		atom := regexAtoms[rand.Intn(len(regexAtoms))]()
+		q := regexQuantifiers[rand.Intn(len(regexQuantifiers))]
+		// Every atom is a single character, class or group, so quantifiers apply to all of it
+		parts = append(parts, atom.pattern+q.suffix)
+		gens = append(gens, func() string {
+			return repeatGen(atom.gen, between(q.min, q.max))
+		})
+	}
+	return "^" + strings.Join(parts, "") + "$", func() string {
+		var b strings.Builder
+		for _, g := range gens {
+			b.WriteString(g())
+		}
+		return b.String()
+	}
+}
+
+func repeatGen(gen func() string, n int) string {
+	var b strings.Builder
+	for range n {
+		b.WriteString(gen())
+	}
+	return b.String()
+}
+
+// mutateRegexText makes a near miss by replacing, inserting or deleting one character
+func mutateRegexText(s string) string {
+	c := string(regexAlphabet[rand.Intn(len(regexAlphabet))])
+	if s == "" {
+		return c
+	}
+	i := rand.Intn(len(s))
+	switch rand.Intn(3) {
+	case 0:
+		return s[:i] + c + s[i+1:]
+	case 1:
+		return s[:i] + c + s[i:]
+	default:
+		return s[:i] + s[i+1:]
+	}
+}
+
+func randomRegexText(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = regexAlphabet[rand.Intn(8)]
+	}
+	return string(b)
+}
+
+// parseIndexSet reads a list of numbers separated by commas or spaces, such as
+// "1,4,7" or "[7, 1, 4]", and returns them sorted without duplicates. Ranges
+// such as "1-4" and other text are rejected.
+func parseIndexSet(answer string) ([]int, bool) {
+	answer = strings.TrimSpace(answer)
+	if inner, ok := strings.CutPrefix(answer, "["); ok {
+		if answer, ok = strings.CutSuffix(inner, "]"); !ok {
+			return nil, false
+		}
+	}
+	fields := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) == 0 {
+		return nil, false
+	}
+	var indices []int
+	for _, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 1 {
+			return nil, false
+		}
+		indices = append(indices, n)
+	}
+	slices.Sort(indices)
+	return slices.Compact(indices), true
+}
@@ -0,0 +1,31 @@
+package puzzles
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// solveRegex is the reference solver: it compiles the pattern and matches every
+// numbered candidate
+func solveRegex(instructions string) (string, bool) {
+	pattern, ok := instructionLine(instructions, "Pattern: ")
+	if !ok {
+		return "", false
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+	var matches []string
+	for _, line := range strings.Split(instructions, "\n") {
+		number, candidate, ok := strings.Cut(line, ". ")
+		if _, err := strconv.Atoi(number); !ok || err != nil {
+			continue
+		}
+		if re.MatchString(candidate) {
+			matches = append(matches, number)
+		}
+	}
+	return strings.Join(matches, ", "), len(matches) > 0
+}
@@ -0,0 +1,29 @@
+package puzzles
+
+import "testing"
+
+func TestRegexAnswerFormats(t *testing.T) {
+	p := NewRegexPuzzle()
+	state := RegexState{Matches: []int{1, 4, 7}}
+	tests := []struct {
+		answer string
+		want   bool
+	}{
+		{"1,4,7", true},
+		{"[7, 1, 4]", true},
+		{"1 4 7", true},
+		{" 4, 7, 1, 7 ", true},
+		{"1,4", false},
+		{"1,4,7,8", false},
+		{"1-4,7", false},
+		{"1-7", false},
+		{"1, 4 and 7", false},
+		{"[1, 4, 7", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.answer, got, tt.want)
+		}
+	}
+}
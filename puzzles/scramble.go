@@ -12,11 +12,13 @@ type ScrambleState struct {
 }
 
 // ScramblePuzzle implements the word unscrambling challenge
-type ScramblePuzzle struct{}
+type ScramblePuzzle struct {
+	scrambleTuning
+}
 
 // NewScramblePuzzle creates a new scramble puzzle instance
 func NewScramblePuzzle() *ScramblePuzzle {
-	return &ScramblePuzzle{}
+	return &ScramblePuzzle{scrambleTuning: newScrambleTuning()}
 }
 
 // Name returns the puzzle identifier
@@ -26,16 +28,22 @@ func (p *ScramblePuzzle) Name() string {
 
 // Generate creates a new scramble challenge
 func (p *ScramblePuzzle) Generate() (instructions string, state any) {
-	word := ChallengeWords[rand.Intn(len(ChallengeWords))]
-	scrambled, descrambleSeq := ScrambleWord(word)
+	d := p.Difficulty()
+	for {
+		word, scrambled, descrambleSeq := p.drawScramble(d.MinWordLength, d.ExtraLetters)
+		hidden, ok := hidePositions(word, scrambled, descrambleSeq, d.HiddenPositions)
+		if !ok {
+			continue
+		}
 
-	instructions = fmt.Sprintf(`Unscramble this word:
+		instructions = fmt.Sprintf(`Unscramble this word:
 
 Scrambled: %s
 Sequence: [%s]`,
-		scrambled, formatSequence(descrambleSeq))
+			scrambled, formatSequence(descrambleSeq, hidden))
 
-	return instructions, ScrambleState{Word: word}
+		return instructions, ScrambleState{Word: word}
+	}
 }
 
 // Validate checks if the answer matches the expected word
@@ -44,13 +52,20 @@ func (p *ScramblePuzzle) Validate(state any, answer string) bool {
 	if !ok {
 		return false
 	}
-	return strings.EqualFold(answer, s.Word)
+	return matchesWord(answer, s.Word)
+}
+
+// Answer returns the expected word, so the puzzle can key another puzzle
+func (p *ScramblePuzzle) Answer(state any) string {
+	s, _ := state.(ScrambleState)
+	return s.Word
 }
 
 var numberWords = []string{
 	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
 	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
-	"seventeen", "eighteen", "nineteen", "twenty",
+	"seventeen", "eighteen", "nineteen", "twenty", "twenty-one", "twenty-two",
+	"twenty-three", "twenty-four", "twenty-five",
 }
 
 func numberToWord(n int) string {
@@ -66,18 +81,14 @@ func numberToWord(n int) string {
 	return word
 }
 
-func formatSequence(seq []int) string {
+func formatSequence(seq []int, hidden map[int]bool) string {
 	strs := make([]string, len(seq))
 	for i, n := range seq {
-		strs[i] = numberToWord(n)
+		if hidden[i] {
+			strs[i] = "--"
+		} else {
+			strs[i] = numberToWord(n)
+		}
 	}
-
-	// Hide random positions
-	hiddenCount := min(HiddenPositions, len(strs))
-	perm := rand.Perm(len(strs))
-	for i := range hiddenCount {
-		strs[perm[i]] = "--"
-	}
-
 	return strings.Join(strs, ", ")
 }
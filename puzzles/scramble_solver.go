@@ -0,0 +1,42 @@
+package puzzles
+
+import "strconv"
+
+// solveScramble is the reference solver: it reads back each shortened number word and
+// fills the hidden positions by trying the unused letters
+func solveScramble(instructions string) (string, bool) {
+	scrambled, ok := instructionLine(instructions, "Scrambled: ")
+	list, ok2 := instructionLine(instructions, "Sequence: ")
+	if !ok || !ok2 {
+		return "", false
+	}
+	var seq []int
+	for _, item := range sequenceItems(list) {
+		if n, err := strconv.Atoi(item); err == nil {
+			seq = append(seq, n)
+			continue
+		}
+		// Hidden and ambiguous positions are both left for descramble to fill
+		n, _ := wordToNumber(item)
+		seq = append(seq, n)
+	}
+	return descramble(scrambled, seq)
+}
+
+// wordToNumber reverses numberToWord, returning 0 if the word is unknown or ambiguous
+func wordToNumber(word string) (int, bool) {
+	found := 0
+	for n, w := range numberWords {
+		match := w == word
+		for i := 0; i < len(w) && !match && len(w) > 4; i++ {
+			match = w[:i]+w[i+1:] == word
+		}
+		if match {
+			if found != 0 {
+				return 0, false
+			}
+			found = n
+		}
+	}
+	return found, found != 0
+}
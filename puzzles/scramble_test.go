@@ -0,0 +1,48 @@
+package puzzles
+
+import (
+	"testing"
+
+	"botcha/challenge"
+)
+
+func TestDrawScrambleFormsRivals(t *testing.T) {
+	p := NewScramblePuzzle()
+	for range 100 {
+		word, scrambled, _ := p.drawScramble(0, ExtraLetters)
+		if n := formableRivals(word, scrambled); n < MinRivals {
+			t.Errorf("%s scrambled as %s forms only %d rivals", word, scrambled, n)
+		}
+	}
+
+	// The longest words without decoys have no rivals, so both are relaxed
+	for range 5 {
+		word, scrambled, _ := p.drawScramble(maxCorpusWordLength, 0)
+		if n := formableRivals(word, scrambled); n < MinRivals {
+			t.Errorf("relaxed: %s scrambled as %s forms only %d rivals", word, scrambled, n)
+		}
+		if len(scrambled) > len(NumberQuestions) {
+			t.Errorf("relaxed: %s has more letters than there are clues", scrambled)
+		}
+	}
+}
+
+func TestMaxDifficultyKeepsRivals(t *testing.T) {
+	d := challenge.DefaultAdaptiveConfig().MaxDifficulty
+	type tunablePuzzle interface {
+		challenge.Puzzle
+		challenge.Tunable
+		challenge.Answerer
+	}
+	for _, p := range []tunablePuzzle{NewScramblePuzzle(), NewCharadePuzzle()} {
+		p.SetDifficulty(d)
+		for range 100 {
+			instructions, state := p.Generate()
+			scrambled, _ := instructionLine(instructions, "Scrambled: ")
+			word := p.Answer(state)
+			if n := formableRivals(word, scrambled); n < MinRivals {
+				t.Errorf("%s: %s scrambled as %s forms only %d rivals", p.Name(), word, scrambled, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package puzzles_test
+
+import (
+	"testing"
+
+	"botcha/puzzles/puzzletest"
+)
+
+func TestBuiltinPuzzles(t *testing.T) {
+	for _, c := range puzzletest.BuiltinCases() {
+		t.Run(c.Puzzle.Name(), func(t *testing.T) {
+			if c.Solve == nil {
+				t.Fatal("no reference solver")
+			}
+			r := puzzletest.Check(c, 200)
+			if !r.OK() || r.Solved != r.Generated {
+				t.Errorf("%d failures, %d/%d solved", r.Failures, r.Solved, r.Generated)
+				for _, s := range r.Samples {
+					t.Log(s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SequenceState holds the puzzle state stored in the session
+type SequenceState struct {
+	Next    []int
+	Letters bool
+}
+
+// SequencePuzzle implements the sequence completion challenge
+type SequencePuzzle struct{}
+
+// NewSequencePuzzle creates a new sequence puzzle instance
+func NewSequencePuzzle() *SequencePuzzle {
+	return &SequencePuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *SequencePuzzle) Name() string {
+	return "sequence"
+}
+
+// seqGenerator builds a sequence of n terms from a randomly parameterized rule.
+// letters reports whether the terms fit the alphabet and may be shown as letters.
+type seqGenerator func(n int) (terms []int, letters bool)
+
+var seqGenerators = []seqGenerator{
+	arithmeticSeq,
+	geometricSeq,
+	quadraticSeq,
+	fibonacciSeq,
+	interleavedSeq,
+	alternatingOpsSeq,
+	primePositionSeq,
+}
+
+// seqHypothesis tries to explain the shown terms and predicts the next count terms
+type seqHypothesis func(shown []int, count int) (next []int, ok bool)
+
+// seqHypotheses are the alternative explanations checked for ambiguity
+var seqHypotheses = []seqHypothesis{
+	fitArithmetic,
+	fitGeometric,
+	fitQuadratic,
+	fitFibonacci,
+	fitInterleaved,
+	fitAlternatingOps,
+	fitLinearRecurrence,
+	fitPrimes,
+}
+
+// Generate creates a new sequence challenge
+func (p *SequencePuzzle) Generate() (instructions string, state any) {
+	for {
+		shownCount := between(6, 8)
+		askCount := between(1, 2)
+		terms, letters := seqGenerators[rand.Intn(len(seqGenerators))](shownCount + askCount)
+		shown, next := terms[:shownCount], terms[shownCount:]
+
+		if !unambiguous(shown, next) {
+			continue
+		}
+
+		question := "What is the next term?"
+		if askCount == 2 {
+			question = "What are the next two terms? Give them in order."
+		}
+
+		instructions = fmt.Sprintf(`Find the rule behind this sequence:
+
+Sequence: %s, ...
+
+%s`, formatTerms(shown, letters), question)
+
+		return instructions, SequenceState{Next: next, Letters: letters}
+	}
+}
+
+// Validate checks if the answer lists the expected terms in order
+func (p *SequencePuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(SequenceState)
+	if !ok {
+		return false
+	}
+	fields := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == ' ' || r == ';' || r == '[' || r == ']' || r == '.' || r == '"' || r == '\''
+	})
+	if len(fields) != len(s.Next) {
+		return false
+	}
+	for i, f := range fields {
+		if s.Letters {
+			if len(f) != 1 || !strings.EqualFold(f, string(rune('a'+s.Next[i]-1))) {
+				return false
+			}
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil || n != s.Next[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// unambiguous reports whether every hypothesis that explains the shown terms
+// predicts the same continuation
+func unambiguous(shown, next []int) bool {
+	for _, fit := range seqHypotheses {
+		predicted, ok := fit(shown, len(next))
+		if ok && !slices.Equal(predicted, next) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatTerms(terms []int, letters bool) string {
+	strs := make([]string, len(terms))
+	for i, t := range terms {
+		if letters {
+			strs[i] = string(rune('A' + t - 1))
+		} else {
+			strs[i] = strconv.Itoa(t)
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+// inAlphabet reports whether all terms are valid letter positions (1-26)
+func inAlphabet(terms []int) bool {
+	for _, t := range terms {
+		if t < 1 || t > 26 {
+			return false
+		}
+	}
+	return true
+}
+
+// asLetters randomly decides to show an alphabet-sized sequence as letters
+func asLetters(terms []int) bool {
+	return inAlphabet(terms) && rand.Intn(2) == 0
+}
+
+// Generators
+
+func arithmeticSeq(n int) ([]int, bool) {
+	a, d := between(1, 20), between(2, 9)
+	if rand.Intn(3) == 0 {
+		a, d = between(1, 3), between(2, 3)
+	}
+	terms := make([]int, n)
+	for i := range terms {
+		terms[i] = a + i*d
+	}
+	return terms, asLetters(terms)
+}
+
+func geometricSeq(n int) ([]int, bool) {
+	a, r := between(1, 5), between(2, 3)
+	terms := make([]int, n)
+	terms[0] = a
+	for i := 1; i < n; i++ {
+		terms[i] = terms[i-1] * r
+	}
+	return terms, false
+}
+
+func quadraticSeq(n int) ([]int, bool) {
+	a, d, dd := between(1, 10), between(1, 5), between(1, 3)
+	terms := make([]int, n)
+	terms[0] = a
+	for i := 1; i < n; i++ {
+		terms[i] = terms[i-1] + d + (i-1)*dd
+	}
+	return terms, asLetters(terms)
+}
+
+func fibonacciSeq(n int) ([]int, bool) {
+	terms := make([]int, n)
+	terms[0], terms[1] = between(1, 5), between(1, 7)
+	for i := 2; i < n; i++ {
+		terms[i] = terms[i-1] + terms[i-2]
+	}
+	return terms, false
+}
+
+// interleavedSeq alternates between two arithmetic progressions
+func interleavedSeq(n int) ([]int, bool) {
+	a, da := between(1, 10), between(1, 6)
+	b, db := between(10, 40), -between(1, 4)
+	if rand.Intn(2) == 0 {
+		db = between(2, 7)
+	}
+	terms := make([]int, n)
+	for i := range terms {
+		if i%2 == 0 {
+			terms[i] = a + (i/2)*da
+		} else {
+			terms[i] = b + (i/2)*db
+		}
+	}
+	return terms, asLetters(terms)
+}
+
+// alternatingOpsSeq alternately adds a constant and multiplies by a factor
+func alternatingOpsSeq(n int) ([]int, bool) {
+	add, mul := between(1, 6), 2
+	terms := make([]int, n)
+	terms[0] = between(1, 5)
+	for i := 1; i < n; i++ {
+		if i%2 == 1 {
+			terms[i] = terms[i-1] + add
+		} else {
+			terms[i] = terms[i-1] * mul
+		}
+	}
+	return terms, false
+}
+
+var smallPrimes = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71}
+
+// primePositionSeq lists consecutive primes, shown as letters when they fit the alphabet
+func primePositionSeq(n int) ([]int, bool) {
+	start := rand.Intn(len(smallPrimes) - n + 1)
+	terms := slices.Clone(smallPrimes[start : start+n])
+	return terms, inAlphabet(terms)
+}
+
+// Hypotheses
+
+func fitArithmetic(shown []int, count int) ([]int, bool) {
+	d := shown[1] - shown[0]
+	for i := 2; i < len(shown); i++ {
+		if shown[i]-shown[i-1] != d {
+			return nil, false
+		}
+	}
+	next := make([]int, count)
+	last := shown[len(shown)-1]
+	for i := range next {
+		last += d
+		next[i] = last
+	}
+	return next, true
+}
+
+func fitGeometric(shown []int, count int) ([]int, bool) {
+	if shown[0] == 0 || shown[1]%shown[0] != 0 {
+		return nil, false
+	}
+	r := shown[1] / shown[0]
+	for i := 2; i < len(shown); i++ {
+		if shown[i] != shown[i-1]*r {
+			return nil, false
+		}
+	}
+	next := make([]int, count)
+	last := shown[len(shown)-1]
+	for i := range next {
+		last *= r
+		next[i] = last
+	}
+	return next, true
+}
+
+func fitQuadratic(shown []int, count int) ([]int, bool) {
+	diffs := make([]int, len(shown)-1)
+	for i := range diffs {
+		diffs[i] = shown[i+1] - shown[i]
+	}
+	nextDiffs, ok := fitArithmetic(diffs, count)
+	if !ok {
+		return nil, false
+	}
+	next := make([]int, count)
+	last := shown[len(shown)-1]
+	for i, d := range nextDiffs {
+		last += d
+		next[i] = last
+	}
+	return next, true
+}
+
+func fitFibonacci(shown []int, count int) ([]int, bool) {
+	for i := 2; i < len(shown); i++ {
+		if shown[i] != shown[i-1]+shown[i-2] {
+			return nil, false
+		}
+	}
+	all := slices.Clone(shown)
+	for range count {
+		all = append(all, all[len(all)-1]+all[len(all)-2])
+	}
+	return all[len(shown):], true
+}
+
+func fitInterleaved(shown []int, count int) ([]int, bool) {
+	var even, odd []int
+	for i, t := range shown {
+		if i%2 == 0 {
+			even = append(even, t)
+		} else {
+			odd = append(odd, t)
+		}
+	}
+	nextEven, ok1 := fitArithmetic(even, count)
+	nextOdd, ok2 := fitArithmetic(odd, count)
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+	next := make([]int, count)
+	for i := range next {
+		pos := len(shown) + i
+		if pos%2 == 0 {
+			next[i] = nextEven[pos/2-len(even)]
+		} else {
+			next[i] = nextOdd[pos/2-len(odd)]
+		}
+	}
+	return next, true
+}
+
+// fitAlternatingOps checks for alternating "add a" and "multiply by b" steps
+func fitAlternatingOps(shown []int, count int) ([]int, bool) {
+	if shown[1] == 0 || shown[2]%shown[1] != 0 {
+		return nil, false
+	}
+	add, mul := shown[1]-shown[0], shown[2]/shown[1]
+	step := func(i, prev int) int {
+		if i%2 == 1 {
+			return prev + add
+		}
+		return prev * mul
+	}
+	for i := 3; i < len(shown); i++ {
+		if shown[i] != step(i, shown[i-1]) {
+			return nil, false
+		}
+	}
+	next := make([]int, count)
+	last := shown[len(shown)-1]
+	for i := range next {
+		last = step(len(shown)+i, last)
+		next[i] = last
+	}
+	return next, true
+}
+
+// fitLinearRecurrence checks for t[i] = p*t[i-1] + q with integer p and q
+func fitLinearRecurrence(shown []int, count int) ([]int, bool) {
+	d0, d1 := shown[1]-shown[0], shown[2]-shown[1]
+	if d0 == 0 || d1%d0 != 0 {
+		return nil, false
+	}
+	p := d1 / d0
+	q := shown[1] - p*shown[0]
+	for i := 1; i < len(shown); i++ {
+		if shown[i] != p*shown[i-1]+q {
+			return nil, false
+		}
+	}
+	next := make([]int, count)
+	last := shown[len(shown)-1]
+	for i := range next {
+		last = p*last + q
+		next[i] = last
+	}
+	return next, true
+}
+
+// fitPrimes checks for consecutive primes
+func fitPrimes(shown []int, count int) ([]int, bool) {
+	start := slices.Index(smallPrimes, shown[0])
+	if start < 0 || start+len(shown)+count > len(smallPrimes) {
+		return nil, false
+	}
+	if !slices.Equal(shown, smallPrimes[start:start+len(shown)]) {
+		return nil, false
+	}
+	return slices.Clone(smallPrimes[start+len(shown) : start+len(shown)+count]), true
+}
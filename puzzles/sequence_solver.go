@@ -0,0 +1,43 @@
+package puzzles
+
+import (
+	"strconv"
+	"strings"
+)
+
+// solveSequence is the reference solver: it predicts the next terms with the first
+// hypothesis that explains the shown ones
+func solveSequence(instructions string) (string, bool) {
+	line, ok := instructionLine(instructions, "Sequence: ")
+	if !ok {
+		return "", false
+	}
+	letters := false
+	var shown []int
+	for _, item := range strings.Split(strings.TrimSuffix(line, ", ..."), ", ") {
+		if len(item) == 1 && item[0] >= 'A' && item[0] <= 'Z' {
+			letters = true
+			shown = append(shown, int(item[0]-'A')+1)
+			continue
+		}
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return "", false
+		}
+		shown = append(shown, n)
+	}
+
+	count := 1
+	if strings.Contains(instructions, "next two terms") {
+		count = 2
+	}
+	for _, fit := range seqHypotheses {
+		if next, ok := fit(shown, count); ok {
+			if letters && !inAlphabet(next) {
+				continue
+			}
+			return formatTerms(next, letters), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,31 @@
+package puzzles
+
+import "testing"
+
+func TestSequenceAnswerFormats(t *testing.T) {
+	p := NewSequencePuzzle()
+	numbers := SequenceState{Next: []int{21, 34}}
+	letters := SequenceState{Next: []int{7, 9}, Letters: true}
+	tests := []struct {
+		state  SequenceState
+		answer string
+		want   bool
+	}{
+		{numbers, "21, 34", true},
+		{numbers, "[21, 34]", true},
+		{numbers, "21 34.", true},
+		{numbers, "34, 21", false},
+		{numbers, "21", false},
+		{numbers, "21, 34, 55", false},
+		{letters, "G, I", true},
+		{letters, "g i", true},
+		{letters, `"G", "I"`, true},
+		{letters, "GI", false},
+		{letters, "7, 9", false},
+	}
+	for _, tt := range tests {
+		if got := p.Validate(tt.state, tt.answer); got != tt.want {
+			t.Errorf("Validate(%+v, %q) = %t, want %t", tt.state, tt.answer, got, tt.want)
+		}
+	}
+}
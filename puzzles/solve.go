@@ -0,0 +1,140 @@
+package puzzles
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"botcha/puzzles/internal/reference"
+)
+
+// Every puzzle has a reference solver in its _solver.go file that rebuilds the
+// answer from the instructions alone. They are unexported and reach
+// puzzletest through an internal package, so programs using this package
+// cannot call them.
+func init() {
+	reference.Solvers = map[string]reference.Solver{
+		"scramble":      solveScramble,
+		"charade":       solveCharade,
+		"mathstory":     solveMathStory,
+		"codetrace":     solveCodeTrace,
+		"logicgrid":     solveLogicGrid,
+		"cipher":        solveCipher,
+		"jsontransform": solveJSONTransform,
+		"passage":       solvePassage,
+		"sequence":      solveSequence,
+		"asciiart":      solveASCIIArt,
+		"conversion":    solveConversion,
+		"date":          solveDate,
+		"regex":         solveRegex,
+		"table":         solveTable,
+		"storyorder":    solveStoryOrder,
+		"rebus":         solveRebus,
+	}
+	reference.ReadNumber = readNumber
+}
+
+// readNumber reads a sequence item as digits, a shortened number word or a
+// number clue
+func readNumber(item string) (int, bool) {
+	if n, err := strconv.Atoi(item); err == nil {
+		return n, true
+	}
+	if n, ok := wordToNumber(item); ok {
+		return n, true
+	}
+	return charadeClueNumber(item)
+}
+
+// instructionLine returns the rest of the first line that starts with prefix
+func instructionLine(instructions, prefix string) (string, bool) {
+	for _, line := range strings.Split(instructions, "\n") {
+		if rest, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.TrimSpace(rest), true
+		}
+	}
+	return "", false
+}
+
+// clueNumbers maps every clue in NumberQuestions back to its number
+var clueNumbers = sync.OnceValue(func() map[string]int {
+	numbers := make(map[string]int)
+	for n, questions := range NumberQuestions {
+		for _, q := range questions {
+			numbers[q] = n
+		}
+	}
+	return numbers
+})
+
+// numberForClue returns the number a clue stands for
+func numberForClue(clue string) (int, bool) {
+	n, ok := clueNumbers()[clue]
+	return n, ok
+}
+
+// challengeWordSet holds ChallengeWords for membership checks
+var challengeWordSet = sync.OnceValue(func() map[string]bool {
+	set := make(map[string]bool, len(ChallengeWords))
+	for _, w := range ChallengeWords {
+		set[w] = true
+	}
+	return set
+})
+
+// descramble reads the word at the 1-indexed positions of seq in scrambled.
+// Positions given as 0 are hidden: they are filled with unused positions until
+// the result is a challenge word.
+func descramble(scrambled string, seq []int) (string, bool) {
+	var hidden []int
+	used := make(map[int]bool)
+	for i, pos := range seq {
+		if pos == 0 {
+			hidden = append(hidden, i)
+		} else {
+			used[pos] = true
+		}
+	}
+	var unused []int
+	for pos := 1; pos <= len(scrambled); pos++ {
+		if !used[pos] {
+			unused = append(unused, pos)
+		}
+	}
+
+	word := make([]byte, len(seq))
+	var fill func(h int) bool
+	fill = func(h int) bool {
+		if h == len(hidden) {
+			for i, pos := range seq {
+				if pos != 0 {
+					word[i] = scrambled[pos-1]
+				}
+			}
+			return challengeWordSet()[string(word)]
+		}
+		for i, pos := range unused {
+			if pos == 0 {
+				continue
+			}
+			word[hidden[h]] = scrambled[pos-1]
+			unused[i] = 0
+			found := fill(h + 1)
+			unused[i] = pos
+			if found {
+				return true
+			}
+		}
+		return false
+	}
+	if !fill(0) {
+		return "", false
+	}
+	return string(word), true
+}
+
+// sequenceItems splits a "[a, b, c]" list from the instructions
+func sequenceItems(list string) []string {
+	list = strings.TrimSuffix(strings.TrimPrefix(list, "["), "]")
+	return strings.Split(list, ", ")
+}
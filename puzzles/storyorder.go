@@ -0,0 +1,387 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// StoryOrderState holds the puzzle state stored in the session
+type StoryOrderState struct {
+	Order string
+}
+
+// StoryOrderPuzzle implements the sentence reordering challenge
+type StoryOrderPuzzle struct{}
+
+// NewStoryOrderPuzzle creates a new story ordering puzzle instance
+func NewStoryOrderPuzzle() *StoryOrderPuzzle {
+	return &StoryOrderPuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *StoryOrderPuzzle) Name() string {
+	return "storyorder"
+}
+
+// storySentence is one step of a story. link is the start of a word that the
+// sentence shares with the step directly before it and with no other step,
+// such as "batter" in a sentence that bakes the batter mixed just before; the
+// first step has none.
+type storySentence struct {
+	text string
+	link string
+}
+
+// storyCast fills the placeholders of a story template
+type storyCast struct {
+	hero, friend, city, item, crop string
+}
+
+// storyTemplates list their sentences in the correct order. Every sentence
+// after the first picks up a word of the one before it, which makes the order
+// unique. Every sentence has a few phrasings, so the order cannot be learned
+// from the wording.
+var storyTemplates = []func(c storyCast) []storySentence{
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s bought flour and eggs at the market.", c.hero),
+				fmt.Sprintf("At the market, %s picked up flour and a box of eggs.", c.hero)), ""},
+			{pick(
+				fmt.Sprintf("Back home, %s mixed the flour and eggs into a batter.", c.hero),
+				fmt.Sprintf("Once home, %s whisked the eggs into the flour to make a batter.", c.hero)), "eggs"},
+			{pick(
+				"The batter went into a tin, and the tin went into the oven.",
+				fmt.Sprintf("%s poured the batter into a tin and slid it into the oven.", c.hero)), "batter"},
+			{pick(
+				"When the timer rang, the cake came out of the oven golden and risen.",
+				"Forty minutes later the cake was lifted out of the oven, golden and risen."), "oven"},
+			{pick(
+				fmt.Sprintf("Once the cake had cooled, %s cut it and shared it with %s.", c.hero, c.friend),
+				fmt.Sprintf("After letting the cake cool, %s sliced it and gave the first piece to %s.", c.hero, c.friend)), "cake"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s booked a train ticket to %s.", c.hero, c.city),
+				fmt.Sprintf("%s booked a seat on a train to %s.", c.hero, c.city)), ""},
+			{pick(
+				fmt.Sprintf("On the morning of the trip, %s printed the booking and left for the station.", c.hero),
+				fmt.Sprintf("On the day of the journey, %s printed out the booking and headed to the station.", c.hero)), "book"},
+			{pick(
+				fmt.Sprintf("The conductor checked the printed ticket as the train pulled out towards %s.", c.city),
+				fmt.Sprintf("As the train left for %s, a conductor inspected the printout.", c.city)), "print"},
+			{pick(
+				fmt.Sprintf("Stepping off the train in %s, %s waved goodbye to the conductor and walked straight to the hotel.", c.city, c.hero),
+				fmt.Sprintf("Arriving in %s, %s thanked the conductor and went from the platform to the hotel.", c.city, c.hero)), "conductor"},
+			{pick(
+				fmt.Sprintf("That evening, after unpacking at the hotel, %s called %s to say the journey had gone well.", c.hero, c.friend),
+				fmt.Sprintf("Settled into the hotel room that evening, %s phoned %s to report a smooth journey.", c.hero, c.friend)), "hotel"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s dug a new bed at the end of the garden.", c.hero),
+				fmt.Sprintf("%s cleared a patch at the bottom of the garden and dug it over.", c.hero)), ""},
+			{pick(
+				fmt.Sprintf("In the freshly dug bed, %s sowed a row of %s seeds.", c.hero, c.crop),
+				fmt.Sprintf("%s planted %s seeds in the newly dug soil.", c.hero, c.crop)), "dug"},
+			{pick(
+				fmt.Sprintf("A few weeks after the seeds went in, the first %s shoots broke through.", c.crop),
+				fmt.Sprintf("Within a month, green %s shoots sprouted from the seeds.", c.crop)), "seed"},
+			{pick(
+				fmt.Sprintf("By late summer those shoots had grown into a fine crop, and %s harvested it.", c.hero),
+				fmt.Sprintf("The shoots grew strong over the summer, and in August %s harvested the crop.", c.hero)), "shoot"},
+			{pick(
+				fmt.Sprintf("%s took a basket of the harvest round to %s.", c.hero, c.friend),
+				fmt.Sprintf("%s shared part of the harvest with %s.", c.hero, c.friend)), "harvest"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("On the way home, %s left a brand-new %s on the bus.", c.hero, c.item),
+				fmt.Sprintf("Hurrying off the bus, %s left a brand-new %s on the seat.", c.hero, c.item)), ""},
+			{pick(
+				fmt.Sprintf("Realising that the %s had been left behind, %s phoned the bus company.", c.item, c.hero),
+				fmt.Sprintf("As soon as %s noticed the %s had been left behind, a call to the bus company followed.", c.hero, c.item)), "left"},
+			{pick(
+				"The bus company said that a driver had handed it in at the depot.",
+				"The company explained that a driver had found it and taken it to the depot."), "company"},
+			{pick(
+				fmt.Sprintf("The next morning %s went to the depot and recovered the %s.", c.hero, c.item),
+				fmt.Sprintf("Early the following day, %s recovered the %s from the depot.", c.hero, c.item)), "depot"},
+			{pick(
+				fmt.Sprintf("With the %s recovered, %s told %s the whole story over lunch.", c.item, c.hero, c.friend),
+				fmt.Sprintf("Over lunch, %s told %s how the %s had been lost and recovered.", c.hero, c.friend, c.item)), "recover"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s finished writing a long letter to %s.", c.hero, c.friend),
+				fmt.Sprintf("After an evening's work, %s finished a long letter to %s.", c.hero, c.friend)), ""},
+			{pick(
+				fmt.Sprintf("The day after finishing it, %s sealed the letter and posted it.", c.hero),
+				fmt.Sprintf("%s put the finished letter in an envelope and posted it on Monday.", c.hero)), "finish"},
+			{pick(
+				fmt.Sprintf("Two days after it was posted, the letter arrived at %s's door.", c.friend),
+				fmt.Sprintf("On Wednesday the posted letter landed on %s's doormat.", c.friend)), "post"},
+			{pick(
+				fmt.Sprintf("%s picked it up from the door, read it twice and sat down to write a reply.", c.friend),
+				fmt.Sprintf("After carrying it in from the doormat and reading it twice, %s began writing a reply.", c.friend)), "door"},
+			{pick(
+				fmt.Sprintf("%s smiled when the reply finally arrived.", c.hero),
+				fmt.Sprintf("A week later the reply reached %s, who read it with a smile.", c.hero)), "repl"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s noticed that the bicycle's back tyre was flat.", c.hero),
+				fmt.Sprintf("%s found the back tyre of the bicycle completely flat.", c.hero)), ""},
+			{pick(
+				fmt.Sprintf("To find the puncture, %s took the flat tyre off and pumped up the inner tube.", c.hero),
+				fmt.Sprintf("%s removed the flat tyre and inflated the inner tube to look for the hole.", c.hero)), "flat"},
+			{pick(
+				"Air hissed from a tiny hole in the tube, which was marked with chalk.",
+				fmt.Sprintf("A faint hiss gave away a tiny hole in the tube, and %s circled it with chalk.", c.hero)), "tube"},
+			{pick(
+				fmt.Sprintf("%s glued a patch over the chalk mark and refitted the tyre.", c.hero),
+				fmt.Sprintf("A patch went over the chalked hole, and %s put the tyre back on.", c.hero)), "chalk"},
+			{pick(
+				fmt.Sprintf("With the tyre patched, %s rode over to %s's house.", c.hero, c.friend),
+				fmt.Sprintf("Now that the patched tyre held air, %s cycled over to visit %s.", c.hero, c.friend)), "patch"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s saw an advert for a job at a bakery in %s.", c.hero, c.city),
+				fmt.Sprintf("%s spotted an advert from a bakery in %s looking for a new assistant.", c.hero, c.city)), ""},
+			{pick(
+				fmt.Sprintf("That night %s answered the advert with an application.", c.hero),
+				fmt.Sprintf("%s applied through the advert the same evening.", c.hero)), "advert"},
+			{pick(
+				fmt.Sprintf("A week after applying, %s was invited to an interview.", c.hero),
+				fmt.Sprintf("The bakery replied to the application and asked %s to come in for an interview.", c.hero)), "appl"},
+			{pick(
+				fmt.Sprintf("%s answered every question at the interview and was offered the job.", c.hero),
+				fmt.Sprintf("The interview went well, and the bakery offered %s the job on the spot.", c.hero)), "interview"},
+			{pick(
+				fmt.Sprintf("To celebrate the job offer, %s took %s out for dinner.", c.hero, c.friend),
+				fmt.Sprintf("%s and %s celebrated the job offer with dinner in town.", c.hero, c.friend)), "offer"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s adopted a puppy from the animal shelter.", c.hero),
+				fmt.Sprintf("At the animal shelter, %s chose a puppy to take home.", c.hero)), ""},
+			{pick(
+				fmt.Sprintf("The puppy chewed through one of %s's shoes on its very first night away from the shelter.", c.hero),
+				"On its first night out of the shelter, the puppy chewed a shoe to pieces."), "shelter"},
+			{pick(
+				fmt.Sprintf("After losing the shoe, %s signed the puppy up for training classes.", c.hero),
+				fmt.Sprintf("The ruined shoe convinced %s to book puppy training classes.", c.hero)), "shoe"},
+			{pick(
+				"By the end of the classes, the puppy would sit and wait on command.",
+				"After six weeks of classes, the puppy had learned to sit and stay on command."), "class"},
+			{pick(
+				fmt.Sprintf("Proud of the well-behaved dog, %s invited %s round to watch it obey each command.", c.hero, c.friend),
+				fmt.Sprintf("%s showed %s how well the puppy now followed every command.", c.hero, c.friend)), "command"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s and %s planned a picnic in the park for Saturday.", c.hero, c.friend),
+				fmt.Sprintf("%s invited %s to a Saturday picnic in the park.", c.hero, c.friend)), ""},
+			{pick(
+				"On Saturday morning they packed sandwiches and a blanket and set off for the park.",
+				"When Saturday came, the two of them packed a basket and a blanket and walked to the park."), "saturday"},
+			{pick(
+				"Just as they spread out the blanket on the grass, it started to pour with rain.",
+				"They had barely unfolded the picnic blanket when heavy rain began."), "blanket"},
+			{pick(
+				"Soaked by the rain, they ran for shelter in a café.",
+				"Dripping wet from the rain, they hurried into a nearby café."), "rain"},
+			{pick(
+				"Sitting in the café, they ate the sandwiches and laughed about the weather.",
+				"They finished the picnic at a café table while the weather cleared."), "café"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s found a flat in %s and signed the lease.", c.hero, c.city),
+				fmt.Sprintf("%s agreed to rent a flat in %s and signed the contract.", c.hero, c.city)), ""},
+			{pick(
+				fmt.Sprintf("After signing, %s spent a week packing everything into boxes.", c.hero),
+				fmt.Sprintf("With the lease signed, %s began filling boxes with belongings.", c.hero)), "sign"},
+			{pick(
+				"On moving day, a van carried the boxes to the new flat.",
+				"Once everything was packed, a removal van took the boxes to the new flat."), "boxes"},
+			{pick(
+				fmt.Sprintf("Unpacking the last box from the van, %s finally felt at home.", c.hero),
+				"When the final box from the van had been unpacked, the flat at last felt like home."), "van"},
+			{pick(
+				fmt.Sprintf("Feeling at home at last, %s threw a housewarming party and invited %s.", c.hero, c.friend),
+				fmt.Sprintf("To celebrate the new home, %s held a housewarming party, and %s came along.", c.hero, c.friend)), "home"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s booked a driving test six weeks away.", c.hero),
+				fmt.Sprintf("%s booked a driving test for the end of the next month.", c.hero)), ""},
+			{pick(
+				fmt.Sprintf("Every evening after booking, %s had a driving lesson with an instructor.", c.hero),
+				fmt.Sprintf("In the weeks after booking, %s took lessons with an instructor.", c.hero)), "book"},
+			{pick(
+				fmt.Sprintf("On the day of the test, a week after the last lesson, the examiner asked %s to drive through the town centre.", c.hero),
+				fmt.Sprintf("When the test day arrived after the final lesson, %s drove the examiner around the town centre.", c.hero)), "lesson"},
+			{pick(
+				fmt.Sprintf("At the end of the drive the examiner announced that %s had passed.", c.hero),
+				fmt.Sprintf("Back at the test centre, the examiner told %s the result: a pass.", c.hero)), "examiner"},
+			{pick(
+				fmt.Sprintf("The weekend after passing, %s drove %s to the seaside.", c.hero, c.friend),
+				fmt.Sprintf("With the test passed, %s took %s on a drive to the coast.", c.hero, c.friend)), "pass"},
+		}
+	},
+	func(c storyCast) []storySentence {
+		return []storySentence{
+			{pick(
+				fmt.Sprintf("%s saw that a favourite band was playing in %s.", c.hero, c.city),
+				fmt.Sprintf("%s heard that a favourite band had a concert coming up in %s.", c.hero, c.city)), ""},
+			{pick(
+				fmt.Sprintf("Tickets for the band's show went on sale the next day, and %s bought two.", c.hero),
+				fmt.Sprintf("The moment the band released tickets, %s bought a pair.", c.hero)), "band"},
+			{pick(
+				fmt.Sprintf("%s gave the spare ticket to %s so they could go together.", c.hero, c.friend),
+				fmt.Sprintf("Having a ticket to spare, %s offered it to %s, and they agreed to go together.", c.hero, c.friend)), "ticket"},
+			{pick(
+				fmt.Sprintf("On the night of the concert, the two friends travelled to %s together.", c.city),
+				fmt.Sprintf("When the concert night came, they made the trip to %s together.", c.city)), "together"},
+			{pick(
+				"They sang along all night and took the last train home.",
+				"After a night of singing along, they caught the last train home."), "night"},
+		}
+	},
+}
+
+var storyItems = []string{"umbrella", "laptop", "rucksack", "violin", "sketchbook"}
+
+var storyCrops = []string{"carrot", "bean", "pea", "lettuce", "onion"}
+
+// Generate creates a new story ordering challenge
+func (p *StoryOrderPuzzle) Generate() (instructions string, state any) {
+	for {
+		hero, friend := pickTwo(storyNames)
+		cast := storyCast{
+			hero:   hero,
+			friend: friend,
+			city:   storyCities[rand.Intn(len(storyCities))],
+			item:   storyItems[rand.Intn(len(storyItems))],
+			crop:   storyCrops[rand.Intn(len(storyCrops))],
+		}
+		sentences := storyTemplates[rand.Intn(len(storyTemplates))](cast)
+		// A name or place from the cast could repeat a link elsewhere
+		if !hasUniqueOrder(sentences) {
+			continue
+		}
+
+		// shown[i] is the story position of the sentence labelled with the i-th letter
+		shown := rand.Perm(len(sentences))
+		if slices.IsSorted(shown) {
+			continue
+		}
+
+		labels := make([]byte, len(sentences))
+		example := exampleOrder(len(sentences))
+		var lines []string
+		for i, pos := range shown {
+			label := byte('A' + i)
+			labels[pos] = label
+			text := sentences[pos].text
+			lines = append(lines, fmt.Sprintf("%c. %s%s", label, strings.ToUpper(text[:1]), text[1:]))
+		}
+
+		if strings.ReplaceAll(example, ", ", "") == string(labels) {
+			continue
+		}
+
+		instructions = fmt.Sprintf(`These sentences from a short story have been shuffled:
+
+%s
+
+Put them back in the order the events happened.
+Answer with the letters in order, for example: %s`, strings.Join(lines, "\n"), example)
+
+		return instructions, StoryOrderState{Order: string(labels)}
+	}
+}
+
+// Validate checks the order, accepting "CADB", "C, A, D, B", "[C, A, D, B]" or "C -> A -> D -> B"
+func (p *StoryOrderPuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(StoryOrderState)
+	if !ok {
+		return false
+	}
+	tokens := strings.FieldsFunc(strings.ToUpper(answer), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	// A compact answer such as "CADB" is a single token
+	if len(tokens) == 1 {
+		return tokens[0] == s.Order
+	}
+	return strings.Join(tokens, "") == s.Order && len(tokens) == len(s.Order)
+}
+
+// hasUniqueOrder reports whether the text of the sentences allows only the
+// listed order: the link of every sentence after the first must start a word
+// of that sentence and of the one directly before it, and of no other.
+func hasUniqueOrder(sentences []storySentence) bool {
+	for i, s := range sentences {
+		if (s.link == "") != (i == 0) {
+			return false
+		}
+		if i == 0 {
+			continue
+		}
+		var sharing []int
+		for j, other := range sentences {
+			if hasWordStem(other.text, s.link) {
+				sharing = append(sharing, j)
+			}
+		}
+		if !slices.Equal(sharing, []int{i - 1, i}) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasWordStem reports whether a word of text starts with stem, ignoring case
+func hasWordStem(text, stem string) bool {
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if strings.HasPrefix(w, stem) {
+			return true
+		}
+	}
+	return false
+}
+
+// exampleOrder shows the answer format with the labels in reverse
+func exampleOrder(n int) string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = string(rune('A' + n - 1 - i))
+	}
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,64 @@
+package puzzles
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// storyPhrasings lists, for every template, a pattern per story step that
+// matches each phrasing of that step with any cast
+var storyPhrasings = sync.OnceValue(func() [][]*regexp.Regexp {
+	// Placeholders mark where the cast goes; every phrasing is drawn with
+	// overwhelming probability in 200 renders
+	cast := storyCast{hero: "\x01", friend: "\x01", city: "\x01", item: "\x01", crop: "\x01"}
+	var phrasings [][]*regexp.Regexp
+	for _, template := range storyTemplates {
+		steps := make([]map[string]bool, len(template(cast)))
+		for range 200 {
+			for i, s := range template(cast) {
+				if steps[i] == nil {
+					steps[i] = make(map[string]bool)
+				}
+				steps[i][s.text] = true
+			}
+		}
+		patterns := make([]*regexp.Regexp, len(steps))
+		for i, texts := range steps {
+			var alternatives []string
+			for text := range texts {
+				alternatives = append(alternatives, strings.ReplaceAll(regexp.QuoteMeta(text), "\x01", `[\w ]+`))
+			}
+			patterns[i] = regexp.MustCompile(`^(?:` + strings.Join(alternatives, "|") + `)$`)
+		}
+		phrasings = append(phrasings, patterns)
+	}
+	return phrasings
+})
+
+var storyLine = regexp.MustCompile(`(?m)^([A-Z])\. (.+)$`)
+
+// solveStoryOrder finds the template whose steps match every sentence and
+// lists the labels in story order
+func solveStoryOrder(instructions string) (string, bool) {
+	lines := storyLine.FindAllStringSubmatch(instructions, -1)
+	for _, patterns := range storyPhrasings() {
+		if len(patterns) != len(lines) {
+			continue
+		}
+		order := make([]byte, len(lines))
+		for _, line := range lines {
+			for step, pattern := range patterns {
+				if order[step] == 0 && pattern.MatchString(line[2]) {
+					order[step] = line[1][0]
+					break
+				}
+			}
+		}
+		if !slices.Contains(order, 0) {
+			return string(order), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,60 @@
+package puzzles
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStoryTemplatesHaveUniqueOrder(t *testing.T) {
+	for i, template := range storyTemplates {
+		// Phrasings are picked at random, so render each template several times
+		for range 50 {
+			hero, friend := pickTwo(storyNames)
+			cast := storyCast{
+				hero:   hero,
+				friend: friend,
+				city:   storyCities[rand.Intn(len(storyCities))],
+				item:   storyItems[rand.Intn(len(storyItems))],
+				crop:   storyCrops[rand.Intn(len(storyCrops))],
+			}
+			sentences := template(cast)
+			if !hasUniqueOrder(sentences) {
+				t.Fatalf("template %d does not have a unique order", i)
+			}
+			for _, s := range sentences {
+				if strings.Contains(s.text, "%!") || !strings.HasSuffix(s.text, ".") {
+					t.Fatalf("template %d has a malformed sentence: %q", i, s.text)
+				}
+			}
+		}
+	}
+}
+
+func TestReorderedStoryIsRejected(t *testing.T) {
+	cast := storyCast{hero: "Ana", friend: "Ben", city: "Leeds", item: "violin", crop: "bean"}
+	for i, template := range storyTemplates {
+		sentences := template(cast)
+
+		// Moving whole sentences, links included
+		for range 20 {
+			shuffled := slices.Clone(sentences)
+			for slices.Equal(shuffled, sentences) {
+				rand.Shuffle(len(shuffled), func(a, b int) { shuffled[a], shuffled[b] = shuffled[b], shuffled[a] })
+			}
+			if hasUniqueOrder(shuffled) {
+				t.Errorf("template %d: shuffled order accepted: %v", i, shuffled)
+			}
+		}
+
+		// Swapping the text of two neighbouring steps while the links stay put
+		for j := 1; j < len(sentences); j++ {
+			swapped := slices.Clone(sentences)
+			swapped[j-1].text, swapped[j].text = swapped[j].text, swapped[j-1].text
+			if hasUniqueOrder(swapped) {
+				t.Errorf("template %d: steps %d and %d swapped but accepted", i, j-1, j)
+			}
+		}
+	}
+}
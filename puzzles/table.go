@@ -0,0 +1,260 @@
+package puzzles
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TableState holds the puzzle state stored in the session
+type TableState struct {
+	Numeric bool
+	Answer  string
+}
+
+// TablePuzzle implements the table lookup and aggregation challenge
+type TablePuzzle struct{}
+
+// NewTablePuzzle creates a new table puzzle instance
+func NewTablePuzzle() *TablePuzzle {
+	return &TablePuzzle{}
+}
+
+// Name returns the puzzle identifier
+func (p *TablePuzzle) Name() string {
+	return "table"
+}
+
+// tableTheme describes what the rows, columns and values of a table are
+type tableTheme struct {
+	title    string
+	rowLabel string
+	rows     []string
+	columns  func() []string
+	unit     string
+	lo, hi   int
+}
+
+var tableThemes = []tableTheme{
+	{"Sales by region and month", "Region", []string{"North", "South", "East", "West", "Central", "Coastal"}, monthColumns, "units sold", 10, 99},
+	{"Visitors by museum and weekday", "Museum", []string{"Science", "History", "Art", "Maritime", "Railway", "Natural"}, weekdayColumns, "visitors", 40, 400},
+	{"Rainfall by city and month (mm)", "City", storyCities, monthColumns, "mm of rain", 5, 120},
+	{"Books borrowed by branch and month", "Branch", []string{"Riverside", "Hilltop", "Old Town", "Harbour", "Parkside", "Station"}, monthColumns, "books", 20, 250},
+}
+
+// monthColumns returns four to six consecutive month abbreviations
+func monthColumns() []string {
+	start := between(1, 7)
+	cols := make([]string, between(4, 6))
+	for i := range cols {
+		cols[i] = time.Month(start + i).String()[:3]
+	}
+	return cols
+}
+
+func weekdayColumns() []string {
+	cols := make([]string, between(4, 6))
+	for i := range cols {
+		cols[i] = time.Weekday(1 + i).String()[:3]
+	}
+	return cols
+}
+
+// dataTable is a generated table of values
+type dataTable struct {
+	theme   tableTheme
+	rows    []string
+	columns []string
+	values  [][]int
+}
+
+func randomTable() dataTable {
+	theme := tableThemes[rand.Intn(len(tableThemes))]
+	t := dataTable{theme: theme, columns: theme.columns()}
+	for _, i := range rand.Perm(len(theme.rows))[:between(3, 5)] {
+		t.rows = append(t.rows, theme.rows[i])
+	}
+	t.values = make([][]int, len(t.rows))
+	for r := range t.values {
+		t.values[r] = make([]int, len(t.columns))
+		for c := range t.values[r] {
+			t.values[r][c] = between(theme.lo, theme.hi)
+		}
+	}
+	return t
+}
+
+// render draws the table as aligned plain text
+func (t dataTable) render() string {
+	width := len(t.theme.rowLabel)
+	for _, r := range t.rows {
+		width = max(width, len(r))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n%-*s", t.theme.title, width, t.theme.rowLabel)
+	for _, c := range t.columns {
+		fmt.Fprintf(&b, " %5s", c)
+	}
+	b.WriteString("\n")
+	for r, name := range t.rows {
+		fmt.Fprintf(&b, "%-*s", width, name)
+		for _, v := range t.values[r] {
+			fmt.Fprintf(&b, " %5d", v)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// rowTotal sums a row over the given columns
+func (t dataTable) rowTotal(row int, cols []int) int {
+	total := 0
+	for _, c := range cols {
+		total += t.values[row][c]
+	}
+	return total
+}
+
+// tableQuestions each ask about a table and return the answer; ok is false when
+// the answer would not be unique
+var tableQuestions = []func(t dataTable) (question string, numeric bool, answer string, ok bool){
+	highestRowInRange,
+	rowTotalInRange,
+	lowestColumn,
+	rowDifference,
+	countAboveThreshold,
+}
+
+// Generate creates a new table challenge
+func (p *TablePuzzle) Generate() (instructions string, state any) {
+	for {
+		t := randomTable()
+		question, numeric, answer, ok := tableQuestions[rand.Intn(len(tableQuestions))](t)
+		if !ok {
+			continue
+		}
+
+		instructions = fmt.Sprintf(`Answer the question using this table:
+
+%s
+Question: %s`, t.render(), question)
+
+		return instructions, TableState{Numeric: numeric, Answer: answer}
+	}
+}
+
+// Validate checks the answer, accepting number words and names in any case
+func (p *TablePuzzle) Validate(state any, answer string) bool {
+	s, ok := state.(TableState)
+	if !ok {
+		return false
+	}
+	if s.Numeric {
+		// parseFloatAnswer skips trailing units such as "mm" or "visitors"
+		got, ok := parseFloatAnswer(answer)
+		want, _ := strconv.Atoi(s.Answer)
+		return ok && got == float64(want)
+	}
+	answer = strings.Trim(strings.TrimSpace(answer), ".!\"'")
+	if strings.EqualFold(answer, s.Answer) {
+		return true
+	}
+	// Column headings are abbreviated; accept full month and weekday names too
+	if m, ok := monthByPrefix(strings.ToLower(answer)); ok {
+		return m.String()[:3] == s.Answer
+	}
+	if d, ok := weekdayByPrefix(strings.ToLower(answer), 3); ok {
+		return d.String()[:3] == s.Answer
+	}
+	return false
+}
+
+// randomColumnRange picks a span of at least three columns, sometimes with one
+// column strictly between its ends excluded, and describes the selection
+func (t dataTable) randomColumnRange() ([]int, string) {
+	from := rand.Intn(len(t.columns) - 2)
+	to := between(from+2, len(t.columns)-1)
+	span := fmt.Sprintf("from %s to %s", t.columns[from], t.columns[to])
+
+	skip := -1
+	if rand.Intn(2) == 0 {
+		skip = between(from+1, to-1)
+		span += fmt.Sprintf(", excluding %s", t.columns[skip])
+	}
+
+	var cols []int
+	for c := from; c <= to; c++ {
+		if c != skip {
+			cols = append(cols, c)
+		}
+	}
+	return cols, span
+}
+
+func highestRowInRange(t dataTable) (string, bool, string, bool) {
+	cols, span := t.randomColumnRange()
+	best, bestTotal, ties := 0, -1, 0
+	for r := range t.rows {
+		total := t.rowTotal(r, cols)
+		if total > bestTotal {
+			best, bestTotal, ties = r, total, 1
+		} else if total == bestTotal {
+			ties++
+		}
+	}
+	return fmt.Sprintf("Which %s had the highest total %s?", strings.ToLower(t.theme.rowLabel), span),
+		false, t.rows[best], ties == 1
+}
+
+func rowTotalInRange(t dataTable) (string, bool, string, bool) {
+	cols, span := t.randomColumnRange()
+	row := rand.Intn(len(t.rows))
+	return fmt.Sprintf("What is the total for %s %s, in %s?", t.rows[row], span, t.theme.unit),
+		true, strconv.Itoa(t.rowTotal(row, cols)), true
+}
+
+func lowestColumn(t dataTable) (string, bool, string, bool) {
+	best, bestTotal, ties := 0, -1, 0
+	for c := range t.columns {
+		total := 0
+		for r := range t.rows {
+			total += t.values[r][c]
+		}
+		if bestTotal < 0 || total < bestTotal {
+			best, bestTotal, ties = c, total, 1
+		} else if total == bestTotal {
+			ties++
+		}
+	}
+	return "Which column has the lowest total across all rows? Answer with its heading.",
+		false, t.columns[best], ties == 1
+}
+
+func rowDifference(t dataTable) (string, bool, string, bool) {
+	perm := rand.Perm(len(t.rows))
+	a, b := perm[0], perm[1]
+	c := rand.Intn(len(t.columns))
+	diff := t.values[a][c] - t.values[b][c]
+	if diff < 0 {
+		diff = -diff
+	}
+	return fmt.Sprintf("In the %s column, how many more %s did the higher of %s and %s have than the other?",
+		t.columns[c], t.theme.unit, t.rows[a], t.rows[b]), true, strconv.Itoa(diff), diff != 0
+}
+
+func countAboveThreshold(t dataTable) (string, bool, string, bool) {
+	threshold := between(t.theme.lo, t.theme.hi)
+	count := 0
+	for r := range t.rows {
+		for c := range t.columns {
+			if t.values[r][c] > threshold {
+				count++
+			}
+		}
+	}
+	return fmt.Sprintf("How many cells in the table have a value greater than %d?", threshold),
+		true, strconv.Itoa(count), true
+}
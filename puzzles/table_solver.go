@@ -0,0 +1,136 @@
+package puzzles
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Patterns for the questions asked by tableQuestions, used by solveTable
+var (
+	highestRowPattern = regexp.MustCompile(`^Which \w+ had the highest total (.+)\?$`)
+	rowTotalPattern   = regexp.MustCompile(`^What is the total for (.+?) (from .+), in .+\?$`)
+	differencePattern = regexp.MustCompile(`^In the (\w+) column, how many more .+ did the higher of (.+) and (.+) have than the other\?$`)
+	thresholdPattern  = regexp.MustCompile(`^How many cells in the table have a value greater than (\d+)\?$`)
+)
+
+// solveTable is the reference solver: it parses the table back and answers the
+// question from its values
+func solveTable(instructions string) (string, bool) {
+	question, ok := instructionLine(instructions, "Question: ")
+	if !ok {
+		return "", false
+	}
+	t, ok := parseTable(instructions)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case highestRowPattern.MatchString(question):
+		cols, ok := t.parseColumnSpan(highestRowPattern.FindStringSubmatch(question)[1])
+		if !ok {
+			return "", false
+		}
+		best := 0
+		for r := range t.rows {
+			if t.rowTotal(r, cols) > t.rowTotal(best, cols) {
+				best = r
+			}
+		}
+		return t.rows[best], true
+	case rowTotalPattern.MatchString(question):
+		m := rowTotalPattern.FindStringSubmatch(question)
+		row := slices.Index(t.rows, m[1])
+		cols, ok := t.parseColumnSpan(m[2])
+		if row < 0 || !ok {
+			return "", false
+		}
+		return strconv.Itoa(t.rowTotal(row, cols)), true
+	case strings.HasPrefix(question, "Which column has the lowest total"):
+		best, bestTotal := 0, -1
+		for c := range t.columns {
+			total := 0
+			for r := range t.rows {
+				total += t.values[r][c]
+			}
+			if bestTotal < 0 || total < bestTotal {
+				best, bestTotal = c, total
+			}
+		}
+		return t.columns[best], true
+	case differencePattern.MatchString(question):
+		m := differencePattern.FindStringSubmatch(question)
+		c := slices.Index(t.columns, m[1])
+		a, b := slices.Index(t.rows, m[2]), slices.Index(t.rows, m[3])
+		if c < 0 || a < 0 || b < 0 {
+			return "", false
+		}
+		diff := t.values[a][c] - t.values[b][c]
+		return strconv.Itoa(max(diff, -diff)), true
+	case thresholdPattern.MatchString(question):
+		threshold, _ := strconv.Atoi(thresholdPattern.FindStringSubmatch(question)[1])
+		count := 0
+		for _, row := range t.values {
+			for _, v := range row {
+				if v > threshold {
+					count++
+				}
+			}
+		}
+		return strconv.Itoa(count), true
+	}
+	return "", false
+}
+
+// parseTable reads a rendered table back from the instructions. Row names may
+// contain spaces, so the values are taken from the end of each line.
+func parseTable(instructions string) (dataTable, bool) {
+	var t dataTable
+	lines := strings.Split(instructions, "\n")
+	if len(lines) < 6 {
+		return t, false
+	}
+	// lines[2] is the title and lines[4] the header
+	header := strings.Fields(lines[4])
+	if len(header) < 2 {
+		return t, false
+	}
+	t.columns = header[1:]
+	for _, line := range lines[5:] {
+		fields := strings.Fields(line)
+		if len(fields) <= len(t.columns) {
+			break
+		}
+		split := len(fields) - len(t.columns)
+		values := make([]int, 0, len(t.columns))
+		for _, f := range fields[split:] {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return t, false
+			}
+			values = append(values, v)
+		}
+		t.rows = append(t.rows, strings.Join(fields[:split], " "))
+		t.values = append(t.values, values)
+	}
+	return t, len(t.rows) > 0
+}
+
+// parseColumnSpan reads a selection written by randomColumnRange
+func (t dataTable) parseColumnSpan(span string) ([]int, bool) {
+	span, excluded, _ := strings.Cut(span, ", excluding ")
+	from, to, ok := strings.Cut(strings.TrimPrefix(span, "from "), " to ")
+	first, last := slices.Index(t.columns, from), slices.Index(t.columns, to)
+	if !ok || first < 0 || last < first {
+		return nil, false
+	}
+	var cols []int
+	for c := first; c <= last; c++ {
+		if t.columns[c] != excluded {
+			cols = append(cols, c)
+		}
+	}
+	return cols, true
+}
@@ -0,0 +1,37 @@
+package puzzles
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestColumnRangeExcludesAnInnerColumn(t *testing.T) {
+	for range 500 {
+		table := randomTable()
+		cols, span := table.randomColumnRange()
+		ends, skipped, _ := strings.Cut(strings.TrimPrefix(span, "from "), ", excluding ")
+		first, last, _ := strings.Cut(ends, " to ")
+		from, to := slices.Index(table.columns, first), slices.Index(table.columns, last)
+		if from < 0 || to-from < 2 {
+			t.Fatalf("%q does not span three columns of %v", span, table.columns)
+		}
+
+		skip := -1
+		if skipped != "" {
+			skip = slices.Index(table.columns, skipped)
+			if skip <= from || skip >= to {
+				t.Fatalf("%q excludes a column outside its inner columns", span)
+			}
+		}
+		var want []int
+		for c := from; c <= to; c++ {
+			if c != skip {
+				want = append(want, c)
+			}
+		}
+		if !slices.Equal(cols, want) {
+			t.Fatalf("%q selects columns %v, want %v", span, cols, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"botcha/puzzles/puzzletest"
+)
+
+// runSelfTest generates challenges from every built-in puzzle, checks them and
+// their reference answers with puzzletest and returns the process exit code
+func runSelfTest(args []string) int {
+	fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+	n := fs.Int("n", 200, "challenges to generate per puzzle")
+	verbose := fs.Bool("v", false, "print sample failures")
+	fs.Parse(args)
+
+	failed := 0
+	for _, c := range puzzletest.BuiltinCases() {
+		r := puzzletest.Check(c, *n)
+		status := "ok"
+		if !r.OK() || !r.HasSolver {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Printf("%-4s  %-14s %5d generated  %5d solved  %d failures\n", status, r.Puzzle, r.Generated, r.Solved, r.Failures)
+		if *verbose {
+			for _, s := range r.Samples {
+				fmt.Println(s)
+			}
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d puzzles failed\n", failed)
+		return 1
+	}
+	return 0
+}